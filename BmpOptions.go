@@ -0,0 +1,15 @@
+package sbm
+
+// BmpOptions are parameters of a monochrome BMP file.
+type BmpOptions struct {
+	// TopDown rows order is used when set, bottom-up order otherwise.
+	TopDown bool
+
+	// InvertedPalette stores white colour as index zero and black colour as
+	// index one. Normal palette follows the SBM bit meaning.
+	InvertedPalette bool
+
+	// Resolution in pixels per metre.
+	XPixelsPerMeter int32
+	YPixelsPerMeter int32
+}
@@ -0,0 +1,14 @@
+package sbm
+
+// DecodeOptions are limits of decoding SBM files from untrusted sources.
+// Zero value of a limit means no limit.
+type DecodeOptions struct {
+	// Maximum dimensions of the pixel array.
+	MaxWidth  uint
+	MaxHeight uint
+	MaxArea   uint
+
+	// MaxHeaderLineLength is the maximum length of a header line including
+	// the CR and LF characters.
+	MaxHeaderLineLength uint
+}
@@ -0,0 +1,27 @@
+package sbm
+
+// DecodeWarning is a description of a defect of an SBM file which has been
+// repaired by the lenient decoder.
+type DecodeWarning struct {
+	// Section is the part of the file.
+	Section ParseSection
+
+	// Header is the name of the header, empty for the pixel array and for the
+	// separator.
+	Header string
+
+	// Line is the number of the line in the file.
+	Line int
+
+	// Offset is the position of the first byte of the line in the file.
+	// It is -1 when the position is unknown.
+	Offset int64
+
+	// Message describes the defect and its repair.
+	Message string
+}
+
+// String returns the text of the warning.
+func (w DecodeWarning) String() string {
+	return formatPosition(w.Section, w.Header, w.Line, w.Offset) + ": " + w.Message
+}
@@ -0,0 +1,15 @@
+package sbm
+
+// Decoder reads SBM objects from the stream reusing its buffers. The stream
+// may contain several objects one after another. A Decoder is not safe for
+// concurrent use.
+type Decoder struct {
+	opts   DecodeOptions
+	reader countingReader
+
+	// Buffer of a header line.
+	line []byte
+
+	// Buffer of a single read.
+	scratch [2]byte
+}
@@ -0,0 +1,9 @@
+package sbm
+
+// DisplayOptions are parameters of a display controller's frame buffer.
+type DisplayOptions struct {
+	// BlackIsOne sets the one bit to be a black pixel. OLED controllers
+	// light the one bits, so this option makes black pixels lit. Most
+	// e-paper controllers use the one bit for white.
+	BlackIsOne bool
+}
@@ -0,0 +1,8 @@
+package sbm
+
+// EncodeOptions are parameters of writing SBM objects.
+type EncodeOptions struct {
+	// Split are the options of splitting the header values. Nil value means
+	// that the values stored in the objects are written.
+	Split *SplitOptions
+}
@@ -0,0 +1,12 @@
+package sbm
+
+import (
+	"io"
+)
+
+// Encoder writes SBM objects into the stream, every object is written with a
+// single call of the stream.
+type Encoder struct {
+	writer io.Writer
+	opts   EncodeOptions
+}
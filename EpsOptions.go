@@ -0,0 +1,25 @@
+package sbm
+
+// EPS data encodings.
+const (
+	EpsEncodingASCIIHex = 1
+	EpsEncodingASCII85  = 2
+)
+
+// EpsOptions are parameters of an EPS file.
+type EpsOptions struct {
+	// DPI is the resolution of the image. Zero value means 72 dots per inch,
+	// i.e. a pixel takes one point.
+	DPI uint
+
+	// ImageMask paints black pixels only using the 'imagemask' operator.
+	// The 'image' operator is used otherwise.
+	ImageMask bool
+
+	// Encoding is one of the EpsEncodingXXX values. Zero value means the
+	// ASCIIHex encoding.
+	Encoding byte
+
+	// RunLength compresses the data before encoding it.
+	RunLength bool
+}
@@ -0,0 +1,38 @@
+package sbm
+
+// ESC/POS bit image modes.
+const (
+	// EscPosModeRaster uses the 'GS v 0' raster bit image command.
+	EscPosModeRaster = 0
+
+	// EscPosModeColumn8 uses the 'ESC *' command with 8-dot columns in
+	// double density.
+	EscPosModeColumn8 = 1
+
+	// EscPosModeColumn24 uses the 'ESC *' command with 24-dot columns in
+	// double density.
+	EscPosModeColumn24 = 2
+)
+
+// ESC/POS printer widths in dots.
+const (
+	EscPosPrinterWidth58mm = 384
+	EscPosPrinterWidth80mm = 576
+)
+
+// EscPosOptions are parameters of ESC/POS printing.
+type EscPosOptions struct {
+	// Mode is one of the EscPosModeXXX values.
+	Mode byte
+
+	// BandHeight is the maximum number of rows in a single raster command.
+	// Zero value means the default height.
+	BandHeight uint
+
+	// PrinterWidth pads rows with white dots up to the printer's width.
+	// Zero value means no padding.
+	PrinterWidth uint
+
+	// Center places the image in the middle of the printer's width.
+	Center bool
+}
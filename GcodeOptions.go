@@ -0,0 +1,27 @@
+package sbm
+
+// GcodeOptions are parameters of laser raster engraving.
+type GcodeOptions struct {
+	// DPI is the resolution of the engraving. Zero value means 254 dots per
+	// inch, i.e. a pixel is 0.1 millimetre.
+	DPI uint
+
+	// FeedRate is the speed of burning moves in millimetres per minute.
+	// Zero value means 1000.
+	FeedRate uint
+
+	// Power is the spindle speed value of the laser power. Zero value means
+	// 1000, which is the maximum power in the default GRBL settings.
+	Power uint
+
+	// ConstantPower uses the 'M3' command. Dynamic power, which depends on
+	// the speed, is used by default with the 'M4' command.
+	ConstantPower bool
+
+	// Serpentine burns every other row from right to left.
+	Serpentine bool
+
+	// Position of the bottom left corner of the image in millimetres.
+	OriginX float64
+	OriginY float64
+}
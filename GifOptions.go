@@ -0,0 +1,13 @@
+package sbm
+
+// GifOptions are parameters of a GIF animation.
+type GifOptions struct {
+	// Delays are the delay times of frames in hundredths of a second. When
+	// set, it must have a delay for each frame.
+	Delays []int
+
+	// LoopCount controls the number of times an animation is restarted.
+	// Zero value loops forever, -1 shows each frame once, other values loop
+	// the animation LoopCount+1 times.
+	LoopCount int
+}
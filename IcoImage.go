@@ -0,0 +1,16 @@
+package sbm
+
+// IcoImage is a monochrome image of an icon or of a cursor.
+type IcoImage struct {
+	// Image is the XOR image.
+	Image *Sbm
+
+	// Mask is the AND mask having the size of the image. White pixels of the
+	// mask are transparent, black pixels are opaque. When it is not set, the
+	// whole image is opaque.
+	Mask *Sbm
+
+	// Hot spot position of a cursor.
+	HotspotX uint16
+	HotspotY uint16
+}
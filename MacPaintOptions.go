@@ -0,0 +1,25 @@
+package sbm
+
+// MacPaint file parameters.
+const (
+	MacPaintWidth         = 576
+	MacPaintHeight        = 720
+	MacPaintPatternsCount = 38
+	MacPaintPatternSize   = 8
+)
+
+// MacPaintOptions are parameters of a MacPaint file.
+type MacPaintOptions struct {
+	// Position of the image on the canvas.
+	X uint
+	Y uint
+
+	// Patterns of the painting tools. When all the patterns are empty, the
+	// file tells the application to use the default patterns.
+	Patterns [MacPaintPatternsCount][MacPaintPatternSize]byte
+
+	// MacBinary wraps the file into a MacBinary II container with the
+	// specified file name.
+	MacBinary bool
+	FileName  string
+}
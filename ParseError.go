@@ -0,0 +1,98 @@
+package sbm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSection is a part of an SBM file.
+type ParseSection byte
+
+// Parts of an SBM file.
+const (
+	ParseSectionTopHeader    ParseSection = 1
+	ParseSectionArray        ParseSection = 2
+	ParseSectionSeparator    ParseSection = 3
+	ParseSectionBottomHeader ParseSection = 4
+)
+
+// Line numbers of the headers. The pixel array together with the separator is
+// counted as a single line.
+const (
+	LineNumberFormat       = 1
+	LineNumberVersion      = 2
+	LineNumberTopWidth     = 3
+	LineNumberTopHeight    = 4
+	LineNumberTopArea      = 5
+	LineNumberArray        = 6
+	LineNumberBottomWidth  = 7
+	LineNumberBottomHeight = 8
+	LineNumberBottomArea   = 9
+)
+
+// ParseError is an error of reading an SBM file, which tells where the error
+// has happened. The underlying error is one of the ErrorXXX values or an
+// error of the stream.
+type ParseError struct {
+	// Section is the part of the file.
+	Section ParseSection
+
+	// Header is the name of the header, empty for the pixel array and for the
+	// separator.
+	Header string
+
+	// Line is the number of the line in the file.
+	Line int
+
+	// Offset is the position of the first byte of the line in the file.
+	// It is -1 when the position is unknown.
+	Offset int64
+
+	// Raw is the data which has been read, it is empty for the pixel array.
+	Raw []byte
+
+	// Err is the underlying error.
+	Err error
+}
+
+// String returns the name of the section.
+func (ps ParseSection) String() string {
+	switch ps {
+	case ParseSectionTopHeader:
+		return "top header"
+	case ParseSectionArray:
+		return "pixel array"
+	case ParseSectionSeparator:
+		return "separator"
+	case ParseSectionBottomHeader:
+		return "bottom header"
+	default:
+		return fmt.Sprintf("section %d", byte(ps))
+	}
+}
+
+// Error returns the text of the error.
+func (e *ParseError) Error() string {
+	return formatPosition(e.Section, e.Header, e.Line, e.Offset) + ": " + e.Err.Error()
+}
+
+// formatPosition returns a text describing a position in the file.
+func formatPosition(section ParseSection, header string, line int, offset int64) string {
+	var sb strings.Builder
+	sb.WriteString(section.String())
+	if len(header) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(header)
+	}
+	sb.WriteString(fmt.Sprintf(", line %d", line))
+	if offset >= 0 {
+		sb.WriteString(fmt.Sprintf(", offset %d", offset))
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,22 @@
+package sbm
+
+// PDF image compressions.
+const (
+	PdfCompressionFlate = 1
+	PdfCompressionCCITT = 2
+)
+
+// PdfOptions are parameters of a PDF document.
+type PdfOptions struct {
+	// DPI is the resolution of images. Zero value means 72 dots per inch,
+	// i.e. a pixel takes one point of the page.
+	DPI uint
+
+	// ImageMask stores images as stencil masks painting black pixels only.
+	// Images use the DeviceGray colour space otherwise.
+	ImageMask bool
+
+	// Compression is one of the PdfCompressionXXX values. Zero value means
+	// the Flate compression.
+	Compression byte
+}
@@ -0,0 +1,20 @@
+package sbm
+
+import (
+	"io"
+)
+
+// ReaderAt reads parts of an SBM file having random access without reading
+// the whole file.
+type ReaderAt struct {
+	reader io.ReaderAt
+
+	// Object having the headers, without the pixel array.
+	sbm Sbm
+
+	// Position of the pixel array in the file.
+	arrayOffset int64
+
+	// Buffer of the bytes read.
+	buffer []byte
+}
@@ -0,0 +1,21 @@
+package sbm
+
+import (
+	"github.com/vault-thirteen/auxie/bit"
+	rdr "github.com/vault-thirteen/auxie/reader"
+)
+
+// RowReader reads an SBM object from the stream row by row.
+type RowReader struct {
+	lineReader *rdr.Reader
+	opts       DecodeOptions
+
+	// Object having the headers, without the pixel array.
+	sbm Sbm
+
+	// Number of rows read.
+	rowsRead uint
+
+	// Bits of the last read byte which do not belong to read rows.
+	pendingBits []bit.Bit
+}
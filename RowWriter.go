@@ -0,0 +1,23 @@
+package sbm
+
+import (
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// RowWriter writes an SBM object into the stream row by row.
+type RowWriter struct {
+	writer io.Writer
+
+	// Object having the headers, without the pixel array.
+	sbm Sbm
+
+	// Number of rows written.
+	rowsWritten uint
+
+	// Bits which do not fill a whole byte yet.
+	pendingBits []bit.Bit
+
+	isClosed bool
+}
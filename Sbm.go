@@ -1,11 +1,23 @@
 package sbm
 
+import (
+	"errors"
+)
+
 const (
 	ErrDimension = "array dimension error"
 )
 
+// Sentinel errors.
+var (
+	ErrorDimension = errors.New(ErrDimension)
+)
+
 // Sbm is a Simple Bit Map.
 type Sbm struct {
 	format     SbmFormat
 	pixelArray SbmPixelArray
+
+	// split are the options of splitting the header values.
+	split SplitOptions
 }
@@ -0,0 +1,16 @@
+package sbm
+
+// Scanner reads SBM objects written one after another into the stream. The
+// stream is read exactly to the end of every object, so that the rest of the
+// stream stays available.
+type Scanner struct {
+	decoder *Decoder
+
+	// Last object read.
+	sbm *Sbm
+
+	// Error of reading, it is nil at the end of the stream.
+	err error
+
+	isFinished bool
+}
@@ -0,0 +1,11 @@
+package sbm
+
+// SixelOptions are parameters of a sixel image.
+type SixelOptions struct {
+	// Scale is the integer scaling factor of pixels. Zero value means no
+	// scaling.
+	Scale uint
+
+	// RunLength compresses repeated sixel characters with the '!' command.
+	RunLength bool
+}
@@ -0,0 +1,40 @@
+package sbm
+
+// Byte layouts of a C array.
+const (
+	// CLayoutAdafruitGFX is the layout of the 'drawBitmap' function of the
+	// Adafruit GFX library: rows start with a new byte, the first pixel is
+	// the most significant bit, the one bit is black.
+	CLayoutAdafruitGFX = 0
+
+	// CLayoutXBM is the XBM layout used by the u8g2 library: rows start with
+	// a new byte, the first pixel is the least significant bit, the one bit
+	// is black.
+	CLayoutXBM = 1
+
+	// CLayoutRaw is the array of bytes of the SBM object as is.
+	CLayoutRaw = 2
+)
+
+// CHeaderOptions are parameters of a generated C header.
+type CHeaderOptions struct {
+	// Name is the identifier of the array. Width and height macros are named
+	// after it in the upper case.
+	Name string
+
+	// Layout is one of the CLayoutXXX values.
+	Layout byte
+
+	// Progmem places the array into the program memory of AVR and similar
+	// microcontrollers.
+	Progmem bool
+}
+
+// GoSourceOptions are parameters of a generated Go source file.
+type GoSourceOptions struct {
+	// Package is the name of the package.
+	Package string
+
+	// Name is the identifier of the package-level variable.
+	Name string
+}
@@ -0,0 +1,34 @@
+package sbm
+
+import (
+	"io"
+	"math/rand/v2"
+)
+
+// Modes of splitting header values into two parts.
+const (
+	// SplitModeRandom splits values at random points.
+	SplitModeRandom = 0
+
+	// SplitModeCanonical splits values deterministically: the left part is
+	// the half of the value rounded down. Identical images are written as
+	// identical files.
+	SplitModeCanonical = 1
+)
+
+// SplitOptions are parameters of splitting the header values into two parts,
+// written in brackets after the values.
+type SplitOptions struct {
+	// Mode is one of the SplitModeXXX values.
+	Mode byte
+
+	// Random is a stream of random bytes used in the random mode. Nil value
+	// means the cryptographic random number generator.
+	Random io.Reader
+
+	// Source is a pseudo-random number generator used in the random mode,
+	// e.g. rand.NewPCG with a fixed seed. It has priority over the Random
+	// stream. The state of the generator changes with every split, so a new
+	// generator must be created to repeat the same splits.
+	Source rand.Source
+}
@@ -0,0 +1,40 @@
+package sbm
+
+// Text rendering styles.
+const (
+	// TextStyleASCII draws every pixel with a character, which is '#' for
+	// black and '.' for white by default.
+	TextStyleASCII = 0
+
+	// TextStyleHalfBlocks draws two pixels, one above the other, with a
+	// Unicode half block.
+	TextStyleHalfBlocks = 1
+
+	// TextStyleQuadrants draws a square of 2x2 pixels with a Unicode
+	// quadrant block.
+	TextStyleQuadrants = 2
+
+	// TextStyleBraille draws 2x4 pixels with a Unicode Braille pattern.
+	TextStyleBraille = 3
+)
+
+// TextOptions are parameters of a text representation.
+type TextOptions struct {
+	// Style is one of the TextStyleXXX values.
+	Style byte
+
+	// Border draws a frame around the image.
+	Border bool
+
+	// Ruler draws pixel coordinates above and to the left of the image.
+	// Coordinates of columns are written modulo ten.
+	Ruler bool
+
+	// Invert draws white pixels instead of black ones.
+	Invert bool
+
+	// Characters of black and white pixels in the ASCII style. Zero values
+	// mean '#' and '.'.
+	Black rune
+	White rune
+}
@@ -0,0 +1,35 @@
+package sbm
+
+// TIFF compression choices.
+const (
+	TiffCompressionNone     = 1
+	TiffCompressionPackBits = 2
+	TiffCompressionG3_1D    = 3
+	TiffCompressionG3_2D    = 4
+	TiffCompressionG4       = 5
+	TiffCompressionMH       = 6
+)
+
+// TIFF resolution units.
+const (
+	TiffResolutionUnitNone       = 1
+	TiffResolutionUnitInch       = 2
+	TiffResolutionUnitCentimeter = 3
+)
+
+// TiffOptions are parameters of a bilevel TIFF file.
+type TiffOptions struct {
+	// Compression is one of the TiffCompressionXXX values. Zero value means
+	// no compression.
+	Compression byte
+
+	// BlackIsZero photometric interpretation is used when set, WhiteIsZero
+	// otherwise.
+	BlackIsZero bool
+
+	// Resolution in pixels per resolution unit. Zero values mean the default
+	// resolution.
+	XResolution    uint32
+	YResolution    uint32
+	ResolutionUnit uint16
+}
@@ -0,0 +1,28 @@
+package sbm
+
+// ZPL graphic field data encodings.
+const (
+	// ZplEncodingHex is the plain hexadecimal data.
+	ZplEncodingHex = 0
+
+	// ZplEncodingCompressed is the hexadecimal data compressed with the ZPL
+	// run-length letter codes.
+	ZplEncodingCompressed = 1
+
+	// ZplEncodingZ64 is the data compressed with zlib and encoded with
+	// Base64.
+	ZplEncodingZ64 = 2
+)
+
+// ZplOptions are parameters of a ZPL graphic field.
+type ZplOptions struct {
+	// Encoding is one of the ZplEncodingXXX values.
+	Encoding byte
+
+	// Label wraps the graphic field into a complete label format.
+	Label bool
+
+	// Position of the field on the label, used with the Label option.
+	X uint
+	Y uint
+}
@@ -0,0 +1,148 @@
+package sbm
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Errors.
+const (
+	ErrRowsRange = "rows are out of range"
+	ErrRectangle = "rectangle is out of bounds"
+)
+
+// Sentinel errors.
+var (
+	ErrorRowsRange = errors.New(ErrRowsRange)
+	ErrorRectangle = errors.New(ErrRectangle)
+)
+
+// OpenReaderAt parses the headers of an SBM file having the size specified.
+// The pixel array is not read, both top and bottom headers are verified.
+func OpenReaderAt(r io.ReaderAt, size int64) (ra *ReaderAt, err error) {
+	ra = &ReaderAt{
+		reader: r,
+	}
+	lineReader := newLineReader(io.NewSectionReader(r, 0, size))
+
+	// Read the top headers.
+	err = ra.sbm.readTopHeaders(lineReader, DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ra.arrayOffset = readerOffset(lineReader)
+
+	// Skip the array.
+	arraySize := int64(ra.sbm.arraySize())
+	if arraySize > size-ra.arrayOffset {
+		return nil, newParseError(ParseSectionArray, "", LineNumberArray, ra.arrayOffset, nil, io.ErrUnexpectedEOF)
+	}
+	err = lineReader.GetInternalReader().(*countingReader).skip(arraySize)
+	if err != nil {
+		return nil, newParseError(ParseSectionArray, "", LineNumberArray, ra.arrayOffset, nil, err)
+	}
+
+	// Read the separator and the bottom headers.
+	err = readSeparator(lineReader)
+	if err != nil {
+		return nil, err
+	}
+	err = ra.sbm.readBottomHeaders(lineReader, DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ra, nil
+}
+
+// Width returns the width of the image.
+func (ra *ReaderAt) Width() uint {
+	return ra.sbm.pixelArray.metaData.width
+}
+
+// Height returns the height of the image.
+func (ra *ReaderAt) Height() uint {
+	return ra.sbm.pixelArray.metaData.height
+}
+
+// Header returns the meta-data of the image.
+func (ra *ReaderAt) Header() Header {
+	return ra.sbm.Header()
+}
+
+// ReadRows reads the rows from y0 up to y1, not including y1, as a new SBM
+// object.
+func (ra *ReaderAt) ReadRows(y0 uint, y1 uint) (sbm *Sbm, err error) {
+	if (y0 >= y1) || (y1 > ra.Height()) {
+		return nil, fmt.Errorf("%w: %d-%d", ErrorRowsRange, y0, y1)
+	}
+
+	return ra.readRect(0, y0, ra.Width(), y1)
+}
+
+// ReadRect reads a rectangular part of the image as a new SBM object. Only
+// the bytes of the rectangle are read.
+func (ra *ReaderAt) ReadRect(rect image.Rectangle) (sbm *Sbm, err error) {
+	bounds := image.Rect(0, 0, int(ra.Width()), int(ra.Height()))
+	if rect.Empty() || !rect.In(bounds) {
+		return nil, fmt.Errorf("%w: %v", ErrorRectangle, rect)
+	}
+
+	return ra.readRect(uint(rect.Min.X), uint(rect.Min.Y), uint(rect.Max.X), uint(rect.Max.Y))
+}
+
+// readRect reads a rectangle of pixels. Whole rows are read with a single
+// reading.
+func (ra *ReaderAt) readRect(x0 uint, y0 uint, x1 uint, y1 uint) (sbm *Sbm, err error) {
+	width := ra.Width()
+	bits := make([]bit.Bit, 0, (x1-x0)*(y1-y0))
+
+	if x1-x0 == width {
+		bits, err = ra.readBits(bits, y0*width, (y1-y0)*width)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for y := y0; y < y1; y++ {
+			bits, err = ra.readBits(bits, y*width+x0, x1-x0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return NewFromBitsArray(bits, x1-x0, y1-y0)
+}
+
+// readBits reads the bits of the pixel array starting at the bit specified.
+// The first bit may be in the middle of a byte.
+func (ra *ReaderAt) readBits(bits []bit.Bit, start uint, count uint) ([]bit.Bit, error) {
+	byteStart := start / bit.BitsPerByte
+	byteEnd := (start + count + bit.BitsPerByte - 1) / bit.BitsPerByte
+	if uint(cap(ra.buffer)) < byteEnd-byteStart {
+		ra.buffer = make([]byte, byteEnd-byteStart)
+	}
+	ra.buffer = ra.buffer[:byteEnd-byteStart]
+
+	offset := ra.arrayOffset + int64(byteStart)
+	// Reading of the last bytes may return io.EOF with the data.
+	n, err := ra.reader.ReadAt(ra.buffer, offset)
+	if n == len(ra.buffer) {
+		err = nil
+	} else if (err == nil) || errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return bits, newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, err)
+	}
+
+	for i := start % bit.BitsPerByte; i < start%bit.BitsPerByte+count; i++ {
+		bits = append(bits, (ra.buffer[i/bit.BitsPerByte]>>(i%bit.BitsPerByte))&1 == 1)
+	}
+
+	return bits, nil
+}
@@ -0,0 +1,103 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// countingReaderAt counts the bytes read from a stream having random access.
+type countingReaderAt struct {
+	reader    io.ReaderAt
+	bytesRead int
+}
+
+func (cra *countingReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	n, err = cra.reader.ReadAt(p, off)
+	cra.bytesRead += n
+	return n, err
+}
+
+func Test_OpenReaderAt(t *testing.T) {
+
+	var err error
+	var ra *ReaderAt
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// The image has a diagonal line and a vertical line at x = 10.
+	bits := diagonalLineBits(13, 20)
+	for y := uint(0); y < 20; y++ {
+		bits[y*13+10] = bit.Zero
+	}
+	source, err := NewFromBitsArray(bits, 13, 20)
+	tst.MustBeNoError(err)
+	buffer := new(bytes.Buffer)
+	tst.MustBeNoError(source.Write(buffer))
+	data := buffer.Bytes()
+
+	// Test #1. Headers.
+	reader := &countingReaderAt{reader: bytes.NewReader(data)}
+	ra, err = OpenReaderAt(reader, int64(len(data)))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(ra.Width(), uint(13))
+	tst.MustBeEqual(ra.Height(), uint(20))
+	tst.MustBeEqual(ra.Header(), source.Header())
+	tst.MustBeEqual(reader.bytesRead, len(data)-len(source.GetArrayBytes()))
+
+	// Test #2. Rows starting in the middle of a byte.
+	reader.bytesRead = 0
+	sbm, err = ra.ReadRows(3, 5)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayWidth(), uint(13))
+	tst.MustBeEqual(sbm.GetArrayBits(), bits[3*13:5*13])
+	tst.MustBeEqual(reader.bytesRead, 5)
+
+	// Test #3. Rectangle.
+	reader.bytesRead = 0
+	sbm, err = ra.ReadRect(image.Rect(9, 2, 12, 6))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayWidth(), uint(3))
+	tst.MustBeEqual(sbm.GetArrayHeight(), uint(4))
+	var expected []bit.Bit
+	for y := uint(2); y < 6; y++ {
+		expected = append(expected, bits[y*13+9:y*13+12]...)
+	}
+	tst.MustBeEqual(sbm.GetArrayBits(), expected)
+	tst.MustBeEqual(reader.bytesRead <= 8, true)
+
+	// Test #4. Last pixel.
+	sbm, err = ra.ReadRect(image.Rect(12, 19, 13, 20))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayBits(), bits[len(bits)-1:])
+
+	// Test #5. Out of range.
+	_, err = ra.ReadRows(5, 5)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorRowsRange), true)
+	_, err = ra.ReadRows(0, 21)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorRowsRange), true)
+	_, err = ra.ReadRect(image.Rect(10, 10, 14, 12))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorRectangle), true)
+
+	// Test #6. Truncated file.
+	_, err = OpenReaderAt(bytes.NewReader(data), int64(len(source.appendTopHeaders(nil))+10))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.ErrUnexpectedEOF), true)
+
+	// Test #7. Damaged bottom header.
+	damaged := bytes.Clone(data)
+	damaged[bytes.LastIndex(damaged, []byte("HEIGHT 20"))+len("HEIGHT 2")] = '1'
+	_, err = OpenReaderAt(bytes.NewReader(damaged), int64(len(damaged)))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorIntegrity), true)
+}
@@ -0,0 +1,262 @@
+package sbm
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// BMP file parameters.
+const (
+	BmpSignature            = "BM"
+	BmpFileHeaderSize       = 14
+	BmpInfoHeaderSize       = 40
+	BmpPaletteEntrySize     = 4
+	BmpPaletteEntriesCount  = 2
+	BmpPlanes               = 1
+	BmpBitsPerPixel         = 1
+	BmpCompressionRGB       = 0
+	BmpRowAlignment         = 4
+	BmpPixelDataOffset      = BmpFileHeaderSize + BmpInfoHeaderSize + BmpPaletteEntriesCount*BmpPaletteEntrySize
+	BmpPaletteEntriesMax    = 256
+	BmpInfoHeaderSizeMax    = 124
+	BmpLuminanceWeightRed   = 299
+	BmpLuminanceWeightGreen = 587
+	BmpLuminanceWeightBlue  = 114
+)
+
+// Errors.
+const (
+	ErrBmpSignature   = "BMP signature error"
+	ErrBmpInfoHeader  = "BMP info header is not supported"
+	ErrBmpBitCount    = "BMP is not a monochrome bitmap"
+	ErrBmpCompression = "BMP compression is not supported"
+	ErrBmpPalette     = "BMP palette error"
+	ErrBmpOffset      = "BMP pixel data offset error"
+	ErrBmpTooLarge    = "image is too large for BMP"
+)
+
+// bmpFileHeader is the BITMAPFILEHEADER structure.
+type bmpFileHeader struct {
+	Signature  [2]byte
+	FileSize   uint32
+	Reserved1  uint16
+	Reserved2  uint16
+	DataOffset uint32
+}
+
+// bmpInfoHeader is the BITMAPINFOHEADER structure.
+type bmpInfoHeader struct {
+	Size             uint32
+	Width            int32
+	Height           int32
+	Planes           uint16
+	BitCount         uint16
+	Compression      uint32
+	ImageSize        uint32
+	XPixelsPerMeter  int32
+	YPixelsPerMeter  int32
+	ColoursUsed      uint32
+	ColoursImportant uint32
+}
+
+// WriteBMP writes an SBM object into the stream as a monochrome BMP file.
+func (sbm *Sbm) WriteBMP(writer io.Writer, opts BmpOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if (width > math.MaxInt32) || (height > math.MaxInt32) {
+		return errors.New(ErrBmpTooLarge)
+	}
+
+	stride := bmpRowStride(width)
+	imageSize := uint64(stride) * uint64(height)
+	if imageSize+BmpPixelDataOffset > math.MaxUint32 {
+		return errors.New(ErrBmpTooLarge)
+	}
+
+	// 1. Headers.
+	fh := bmpFileHeader{
+		Signature:  [2]byte{BmpSignature[0], BmpSignature[1]},
+		FileSize:   uint32(imageSize + BmpPixelDataOffset),
+		DataOffset: BmpPixelDataOffset,
+	}
+	ih := bmpInfoHeader{
+		Size:             BmpInfoHeaderSize,
+		Width:            int32(width),
+		Height:           int32(height),
+		Planes:           BmpPlanes,
+		BitCount:         BmpBitsPerPixel,
+		Compression:      BmpCompressionRGB,
+		ImageSize:        uint32(imageSize),
+		XPixelsPerMeter:  opts.XPixelsPerMeter,
+		YPixelsPerMeter:  opts.YPixelsPerMeter,
+		ColoursUsed:      BmpPaletteEntriesCount,
+		ColoursImportant: BmpPaletteEntriesCount,
+	}
+	if opts.TopDown {
+		ih.Height = -ih.Height
+	}
+
+	err = binary.Write(writer, binary.LittleEndian, fh)
+	if err != nil {
+		return err
+	}
+	err = binary.Write(writer, binary.LittleEndian, ih)
+	if err != nil {
+		return err
+	}
+
+	// 2. Palette.
+	black := []byte{0x00, 0x00, 0x00, 0x00}
+	white := []byte{0xFF, 0xFF, 0xFF, 0x00}
+	palette := append(append([]byte{}, black...), white...)
+	if opts.InvertedPalette {
+		palette = append(append([]byte{}, white...), black...)
+	}
+	_, err = writer.Write(palette)
+	if err != nil {
+		return err
+	}
+
+	// 3. Pixels.
+	row := make([]byte, stride)
+	for i := uint(0); i < height; i++ {
+		y := height - 1 - i
+		if opts.TopDown {
+			y = i
+		}
+
+		clear(row)
+		copy(row, sbm.getRowBytesMSB(y, opts.InvertedPalette))
+		_, err = writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewFromBMP reads an SBM object from the stream containing a monochrome BMP
+// file. Parameters of the BMP file are returned together with the object.
+func NewFromBMP(reader io.Reader) (sbm *Sbm, opts BmpOptions, err error) {
+
+	// 1. File header.
+	var fh bmpFileHeader
+	err = binary.Read(reader, binary.LittleEndian, &fh)
+	if err != nil {
+		return nil, opts, err
+	}
+	if string(fh.Signature[:]) != BmpSignature {
+		return nil, opts, errors.New(ErrBmpSignature)
+	}
+
+	// 2. Info header.
+	var ih bmpInfoHeader
+	err = binary.Read(reader, binary.LittleEndian, &ih)
+	if err != nil {
+		return nil, opts, err
+	}
+	if (ih.Size < BmpInfoHeaderSize) || (ih.Size > BmpInfoHeaderSizeMax) {
+		return nil, opts, errors.New(ErrBmpInfoHeader)
+	}
+	_, err = io.CopyN(io.Discard, reader, int64(ih.Size-BmpInfoHeaderSize))
+	if err != nil {
+		return nil, opts, err
+	}
+	if (ih.Planes != BmpPlanes) || (ih.BitCount != BmpBitsPerPixel) {
+		return nil, opts, errors.New(ErrBmpBitCount)
+	}
+	if ih.Compression != BmpCompressionRGB {
+		return nil, opts, errors.New(ErrBmpCompression)
+	}
+	if (ih.Width <= 0) || (ih.Height == 0) || (ih.Height == math.MinInt32) {
+		return nil, opts, ErrorDimension
+	}
+
+	opts.TopDown = ih.Height < 0
+	opts.XPixelsPerMeter = ih.XPixelsPerMeter
+	opts.YPixelsPerMeter = ih.YPixelsPerMeter
+	width := uint(ih.Width)
+	height := uint(ih.Height)
+	if opts.TopDown {
+		height = uint(-ih.Height)
+	}
+
+	// 3. Palette.
+	paletteEntries := ih.ColoursUsed
+	if paletteEntries == 0 {
+		paletteEntries = BmpPaletteEntriesCount
+	}
+	if (paletteEntries < BmpPaletteEntriesCount) || (paletteEntries > BmpPaletteEntriesMax) {
+		return nil, opts, errors.New(ErrBmpPalette)
+	}
+	palette := make([]byte, paletteEntries*BmpPaletteEntrySize)
+	_, err = io.ReadFull(reader, palette)
+	if err != nil {
+		return nil, opts, err
+	}
+	opts.InvertedPalette = bmpLuminance(palette[0:4]) > bmpLuminance(palette[4:8])
+
+	// 4. Gap before the pixel data.
+	headersSize := uint64(BmpFileHeaderSize) + uint64(ih.Size) + uint64(len(palette))
+	if uint64(fh.DataOffset) < headersSize {
+		return nil, opts, errors.New(ErrBmpOffset)
+	}
+	_, err = io.CopyN(io.Discard, reader, int64(uint64(fh.DataOffset)-headersSize))
+	if err != nil {
+		return nil, opts, err
+	}
+
+	// 5. Pixels. The data is read before the allocation of the bits, so that
+	// the memory is allocated only for the data which really exists.
+	area, ok := multiplyDimensions(width, height)
+	stride := bmpRowStride(width)
+	dataSize, ok2 := multiplyDimensions(stride, height)
+	if !ok || !ok2 || (dataSize > math.MaxInt64) {
+		return nil, opts, ErrorDimension
+	}
+	var data []byte
+	data, err = io.ReadAll(io.LimitReader(reader, int64(dataSize)))
+	if err != nil {
+		return nil, opts, err
+	}
+	if uint(len(data)) != dataSize {
+		return nil, opts, io.ErrUnexpectedEOF
+	}
+
+	bits := make([]bit.Bit, 0, area)
+	for i := uint(0); i < height; i++ {
+		y := height - 1 - i
+		if opts.TopDown {
+			y = i
+		}
+		bits = appendRowBitsMSB(bits, data[y*stride:(y+1)*stride], width, opts.InvertedPalette)
+	}
+
+	sbm, err = NewFromBitsArray(bits, width, height)
+	if err != nil {
+		return nil, opts, err
+	}
+
+	return sbm, opts, nil
+}
+
+// bmpRowStride returns the size of a BMP row in bytes including padding.
+func bmpRowStride(width uint) uint {
+	const rowAlignmentBits = BmpRowAlignment * bit.BitsPerByte
+	return ((width + rowAlignmentBits - 1) / rowAlignmentBits) * BmpRowAlignment
+}
+
+// bmpLuminance returns a relative luminance of the RGBQUAD palette entry.
+func bmpLuminance(entry []byte) uint {
+	return uint(entry[2])*BmpLuminanceWeightRed +
+		uint(entry[1])*BmpLuminanceWeightGreen +
+		uint(entry[0])*BmpLuminanceWeightBlue
+}
@@ -0,0 +1,144 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteBMP(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// 3x2 image: the top row is 1-0-1, the bottom row is 0-0-1.
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Bottom-up.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteBMP(buffer, BmpOptions{XPixelsPerMeter: 11811, YPixelsPerMeter: 11811})
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+	tst.MustBeEqual(len(data), BmpPixelDataOffset+2*4)
+	tst.MustBeEqual(string(data[0:2]), BmpSignature)
+	tst.MustBeEqual(binary.LittleEndian.Uint32(data[2:6]), uint32(len(data)))
+	tst.MustBeEqual(binary.LittleEndian.Uint32(data[10:14]), uint32(BmpPixelDataOffset))
+	tst.MustBeEqual(int32(binary.LittleEndian.Uint32(data[22:26])), int32(2))
+	tst.MustBeEqual(int32(binary.LittleEndian.Uint32(data[38:42])), int32(11811))
+	tst.MustBeEqual(data[54:62], []byte{0, 0, 0, 0, 255, 255, 255, 0})
+	tst.MustBeEqual(data[62:], []byte{
+		0x20, 0, 0, 0,
+		0xA0, 0, 0, 0,
+	})
+
+	// Test #2. Top-down with an inverted palette.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteBMP(buffer, BmpOptions{TopDown: true, InvertedPalette: true})
+	tst.MustBeNoError(err)
+	data = buffer.Bytes()
+	tst.MustBeEqual(int32(binary.LittleEndian.Uint32(data[22:26])), int32(-2))
+	tst.MustBeEqual(data[54:62], []byte{255, 255, 255, 0, 0, 0, 0, 0})
+	tst.MustBeEqual(data[62:], []byte{
+		0x40, 0, 0, 0,
+		0xC0, 0, 0, 0,
+	})
+
+	// Test #3. Empty object.
+	err = new(Sbm).WriteBMP(new(bytes.Buffer), BmpOptions{})
+	tst.MustBeAnError(err)
+}
+
+func Test_NewFromBMP(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var opts BmpOptions
+	var optsList []BmpOptions
+	var result *Sbm
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	bits := make([]bit.Bit, 0, 37*5)
+	for i := 0; i < 37*5; i++ {
+		bits = append(bits, bit.Bit(i%3 == 0))
+	}
+	sbm, err = NewFromBitsArray(bits, 37, 5)
+	tst.MustBeNoError(err)
+
+	// Test #1. Round trip in all the modes.
+	optsList = []BmpOptions{
+		{},
+		{TopDown: true},
+		{InvertedPalette: true, XPixelsPerMeter: 100, YPixelsPerMeter: 200},
+		{TopDown: true, InvertedPalette: true},
+	}
+	for _, o := range optsList {
+		buffer = new(bytes.Buffer)
+		err = sbm.WriteBMP(buffer, o)
+		tst.MustBeNoError(err)
+
+		result, opts, err = NewFromBMP(buffer)
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(opts, o)
+		tst.MustBeEqual(result.GetArrayWidth(), sbm.GetArrayWidth())
+		tst.MustBeEqual(result.GetArrayHeight(), sbm.GetArrayHeight())
+		tst.MustBeEqual(result.GetArrayBits(), sbm.GetArrayBits())
+		tst.MustBeEqual(result.GetArrayBytes(), sbm.GetArrayBytes())
+	}
+
+	// Test #2. Bad signature.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteBMP(buffer, BmpOptions{})
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+	data[0] = 'X'
+	_, _, err = NewFromBMP(bytes.NewReader(data))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrBmpSignature)
+
+	// Test #3. Not a monochrome bitmap.
+	data[0] = 'B'
+	binary.LittleEndian.PutUint16(data[28:30], 8)
+	_, _, err = NewFromBMP(bytes.NewReader(data))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrBmpBitCount)
+
+	// Test #4. Truncated pixel data.
+	binary.LittleEndian.PutUint16(data[28:30], 1)
+	_, _, err = NewFromBMP(bytes.NewReader(data[:len(data)-1]))
+	tst.MustBeAnError(err)
+
+	// Test #5. Bad data offset.
+	binary.LittleEndian.PutUint32(data[10:14], 10)
+	_, _, err = NewFromBMP(bytes.NewReader(data))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrBmpOffset)
+
+	// Test #6. Huge dimensions with a tiny file.
+	binary.LittleEndian.PutUint32(data[10:14], 62)
+	binary.LittleEndian.PutUint32(data[18:22], math.MaxInt32)
+	binary.LittleEndian.PutUint32(data[22:26], math.MaxInt32)
+	_, _, err = NewFromBMP(bytes.NewReader(data))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.ErrUnexpectedEOF), true)
+}
@@ -0,0 +1,613 @@
+package sbm
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// CCITT coding schemes.
+const (
+	// ccittModeMH is the Modified Huffman run length coding without EOL codes,
+	// each row starts at a byte boundary.
+	ccittModeMH = 1
+
+	// ccittModeG3_1D is the one-dimensional T.4 coding with EOL codes.
+	ccittModeG3_1D = 2
+
+	// ccittModeG3_2D is the two-dimensional T.4 coding with EOL codes.
+	ccittModeG3_2D = 3
+
+	// ccittModeG4 is the two-dimensional T.6 coding.
+	ccittModeG4 = 4
+)
+
+// CCITT coding parameters.
+const (
+	ccittG3_2D_K     = 4
+	ccittEolCode     = "000000000001"
+	ccittEolZerosMin = 11
+	ccittRunMakeupX  = 64
+	ccittRunMax      = 2560
+	ccittCodeLenMax  = 13
+	ccittColourWhite = 0
+	ccittColourBlack = 1
+)
+
+// Two-dimensional coding modes.
+const (
+	ccittModePass       = 1
+	ccittModeHorizontal = 2
+	ccittModeVertical   = 10 // Vertical modes are ccittModeVertical + (a1-b1).
+)
+
+// Errors.
+const (
+	ErrCcittMode     = "unknown CCITT coding scheme"
+	ErrCcittCode     = "CCITT code error"
+	ErrCcittRun      = "CCITT run length error"
+	ErrCcittData     = "CCITT data is truncated"
+	ErrCcittRowWidth = "CCITT row width mismatch"
+)
+
+// Terminating and make-up codes of white runs.
+var ccittWhiteCodes = map[int]string{
+	0: "00110101", 1: "000111", 2: "0111", 3: "1000", 4: "1011", 5: "1100",
+	6: "1110", 7: "1111", 8: "10011", 9: "10100", 10: "00111", 11: "01000",
+	12: "001000", 13: "000011", 14: "110100", 15: "110101", 16: "101010",
+	17: "101011", 18: "0100111", 19: "0001100", 20: "0001000", 21: "0010111",
+	22: "0000011", 23: "0000100", 24: "0101000", 25: "0101011", 26: "0010011",
+	27: "0100100", 28: "0011000", 29: "00000010", 30: "00000011",
+	31: "00011010", 32: "00011011", 33: "00010010", 34: "00010011",
+	35: "00010100", 36: "00010101", 37: "00010110", 38: "00010111",
+	39: "00101000", 40: "00101001", 41: "00101010", 42: "00101011",
+	43: "00101100", 44: "00101101", 45: "00000100", 46: "00000101",
+	47: "00001010", 48: "00001011", 49: "01010010", 50: "01010011",
+	51: "01010100", 52: "01010101", 53: "00100100", 54: "00100101",
+	55: "01011000", 56: "01011001", 57: "01011010", 58: "01011011",
+	59: "01001010", 60: "01001011", 61: "00110010", 62: "00110011",
+	63: "00110100",
+	64: "11011", 128: "10010", 192: "010111", 256: "0110111",
+	320: "00110110", 384: "00110111", 448: "01100100", 512: "01100101",
+	576: "01101000", 640: "01100111", 704: "011001100", 768: "011001101",
+	832: "011010010", 896: "011010011", 960: "011010100", 1024: "011010101",
+	1088: "011010110", 1152: "011010111", 1216: "011011000",
+	1280: "011011001", 1344: "011011010", 1408: "011011011",
+	1472: "010011000", 1536: "010011001", 1600: "010011010",
+	1664: "011000", 1728: "010011011",
+}
+
+// Terminating and make-up codes of black runs.
+var ccittBlackCodes = map[int]string{
+	0: "0000110111", 1: "010", 2: "11", 3: "10", 4: "011", 5: "0011",
+	6: "0010", 7: "00011", 8: "000101", 9: "000100", 10: "0000100",
+	11: "0000101", 12: "0000111", 13: "00000100", 14: "00000111",
+	15: "000011000", 16: "0000010111", 17: "0000011000", 18: "0000001000",
+	19: "00001100111", 20: "00001101000", 21: "00001101100",
+	22: "00000110111", 23: "00000101000", 24: "00000010111",
+	25: "00000011000", 26: "000011001010", 27: "000011001011",
+	28: "000011001100", 29: "000011001101", 30: "000001101000",
+	31: "000001101001", 32: "000001101010", 33: "000001101011",
+	34: "000011010010", 35: "000011010011", 36: "000011010100",
+	37: "000011010101", 38: "000011010110", 39: "000011010111",
+	40: "000001101100", 41: "000001101101", 42: "000011011010",
+	43: "000011011011", 44: "000001010100", 45: "000001010101",
+	46: "000001010110", 47: "000001010111", 48: "000001100100",
+	49: "000001100101", 50: "000001010010", 51: "000001010011",
+	52: "000000100100", 53: "000000110111", 54: "000000111000",
+	55: "000000100111", 56: "000000101000", 57: "000001011000",
+	58: "000001011001", 59: "000000101011", 60: "000000101100",
+	61: "000001011010", 62: "000001100110", 63: "000001100111",
+	64: "0000001111", 128: "000011001000", 192: "000011001001",
+	256: "000001011011", 320: "000000110011", 384: "000000110100",
+	448: "000000110101", 512: "0000001101100", 576: "0000001101101",
+	640: "0000001001010", 704: "0000001001011", 768: "0000001001100",
+	832: "0000001001101", 896: "0000001110010", 960: "0000001110011",
+	1024: "0000001110100", 1088: "0000001110101", 1152: "0000001110110",
+	1216: "0000001110111", 1280: "0000001010010", 1344: "0000001010011",
+	1408: "0000001010100", 1472: "0000001010101", 1536: "0000001011010",
+	1600: "0000001011011", 1664: "0000001100100", 1728: "0000001100101",
+}
+
+// Extended make-up codes, common for both colours.
+var ccittExtendedCodes = map[int]string{
+	1792: "00000001000", 1856: "00000001100", 1920: "00000001101",
+	1984: "000000010010", 2048: "000000010011", 2112: "000000010100",
+	2176: "000000010101", 2240: "000000010110", 2304: "000000010111",
+	2368: "000000011100", 2432: "000000011101", 2496: "000000011110",
+	2560: "000000011111",
+}
+
+// Two-dimensional mode codes.
+var ccittModeCodes = map[int]string{
+	ccittModePass:         "0001",
+	ccittModeHorizontal:   "001",
+	ccittModeVertical - 3: "0000010",
+	ccittModeVertical - 2: "000010",
+	ccittModeVertical - 1: "010",
+	ccittModeVertical:     "1",
+	ccittModeVertical + 1: "011",
+	ccittModeVertical + 2: "000011",
+	ccittModeVertical + 3: "0000011",
+}
+
+// ccittCode is a variable length code.
+type ccittCode struct {
+	value uint32
+	size  uint
+}
+
+// ccittTable is a table of variable length codes.
+type ccittTable struct {
+	encode map[int]ccittCode
+	decode map[ccittCode]int
+}
+
+var (
+	ccittTablesOnce sync.Once
+	ccittTableWhite ccittTable
+	ccittTableBlack ccittTable
+	ccittTableMode  ccittTable
+)
+
+// ccittTables returns the code tables for white runs, black runs and
+// two-dimensional modes.
+func ccittTables() (white, black, mode *ccittTable) {
+	ccittTablesOnce.Do(func() {
+		ccittTableWhite = newCcittTable(ccittWhiteCodes, ccittExtendedCodes)
+		ccittTableBlack = newCcittTable(ccittBlackCodes, ccittExtendedCodes)
+		ccittTableMode = newCcittTable(ccittModeCodes)
+	})
+
+	return &ccittTableWhite, &ccittTableBlack, &ccittTableMode
+}
+
+// newCcittTable creates a code table from the textual code lists.
+func newCcittTable(lists ...map[int]string) (t ccittTable) {
+	t.encode = make(map[int]ccittCode)
+	t.decode = make(map[ccittCode]int)
+
+	for _, list := range lists {
+		for n, s := range list {
+			c := parseCcittCode(s)
+			t.encode[n] = c
+			t.decode[c] = n
+		}
+	}
+
+	return t
+}
+
+// parseCcittCode converts a textual code into a code.
+func parseCcittCode(s string) (c ccittCode) {
+	for i := 0; i < len(s); i++ {
+		c.value = c.value<<1 | uint32(s[i]-'0')
+	}
+	c.size = uint(len(s))
+	return c
+}
+
+// ccittBitWriter writes bits with the most significant bit first.
+type ccittBitWriter struct {
+	data  []byte
+	nBits uint
+}
+
+func (w *ccittBitWriter) writeCode(c ccittCode) {
+	for i := c.size; i > 0; i-- {
+		if w.nBits%bit.BitsPerByte == 0 {
+			w.data = append(w.data, 0)
+		}
+		if (c.value>>(i-1))&1 == 1 {
+			w.data[len(w.data)-1] |= 0x80 >> (w.nBits % bit.BitsPerByte)
+		}
+		w.nBits++
+	}
+}
+
+// align moves the writer to the next byte boundary.
+func (w *ccittBitWriter) align() {
+	w.nBits = uint(len(w.data)) * bit.BitsPerByte
+}
+
+// ccittBitReader reads bits with the most significant bit first.
+type ccittBitReader struct {
+	data []byte
+	pos  uint
+}
+
+func (r *ccittBitReader) readBit() (b uint32, err error) {
+	if r.pos >= uint(len(r.data))*bit.BitsPerByte {
+		return 0, errors.New(ErrCcittData)
+	}
+	b = uint32(r.data[r.pos/bit.BitsPerByte]>>(7-r.pos%bit.BitsPerByte)) & 1
+	r.pos++
+	return b, nil
+}
+
+// readCode reads a single code of the table.
+func (r *ccittBitReader) readCode(t *ccittTable) (n int, err error) {
+	var c ccittCode
+	var b uint32
+	for c.size < ccittCodeLenMax {
+		b, err = r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		c.value = c.value<<1 | b
+		c.size++
+
+		var ok bool
+		n, ok = t.decode[c]
+		if ok {
+			return n, nil
+		}
+	}
+
+	return 0, errors.New(ErrCcittCode)
+}
+
+// skipEol skips an EOL code together with its fill bits if it is present.
+func (r *ccittBitReader) skipEol() (found bool) {
+	pos := r.pos
+	zeros := 0
+	for {
+		b, err := r.readBit()
+		if err != nil {
+			r.pos = pos
+			return false
+		}
+		if b == 1 {
+			break
+		}
+		zeros++
+	}
+
+	if zeros < ccittEolZerosMin {
+		r.pos = pos
+		return false
+	}
+
+	return true
+}
+
+// align moves the reader to the next byte boundary.
+func (r *ccittBitReader) align() {
+	r.pos = (r.pos + bit.BitsPerByte - 1) / bit.BitsPerByte * bit.BitsPerByte
+}
+
+// ccittEncode encodes packed rows, where the first pixel is the most
+// significant bit and the one bit is black.
+func ccittEncode(rows [][]byte, width uint, mode byte) (data []byte, err error) {
+	white, black, modes := ccittTables()
+	eol := parseCcittCode(ccittEolCode)
+	w := &ccittBitWriter{}
+
+	ref := []int{}
+	for y, row := range rows {
+		cur := ccittRowChanges(row, int(width))
+
+		switch mode {
+		case ccittModeMH:
+			ccittEncodeRow1D(w, cur, int(width), white, black)
+			w.align()
+
+		case ccittModeG3_1D:
+			w.writeCode(eol)
+			ccittEncodeRow1D(w, cur, int(width), white, black)
+
+		case ccittModeG3_2D:
+			w.writeCode(eol)
+			if y%ccittG3_2D_K == 0 {
+				w.writeCode(ccittCode{value: 1, size: 1})
+				ccittEncodeRow1D(w, cur, int(width), white, black)
+			} else {
+				w.writeCode(ccittCode{value: 0, size: 1})
+				ccittEncodeRow2D(w, cur, ref, int(width), white, black, modes)
+			}
+
+		case ccittModeG4:
+			ccittEncodeRow2D(w, cur, ref, int(width), white, black, modes)
+
+		default:
+			return nil, errors.New(ErrCcittMode)
+		}
+
+		ref = cur
+	}
+
+	// End of facsimile block.
+	if mode == ccittModeG4 {
+		w.writeCode(eol)
+		w.writeCode(eol)
+	}
+
+	return w.data, nil
+}
+
+// ccittEncodeRow1D encodes a row using the run lengths.
+func ccittEncodeRow1D(w *ccittBitWriter, changes []int, width int, white, black *ccittTable) {
+	pos := 0
+	colour := ccittColourWhite
+	for _, c := range changes {
+		ccittWriteRun(w, c-pos, colour, white, black)
+		pos = c
+		colour ^= 1
+	}
+	ccittWriteRun(w, width-pos, colour, white, black)
+}
+
+// ccittEncodeRow2D encodes a row relative to the reference row.
+func ccittEncodeRow2D(w *ccittBitWriter, cur, ref []int, width int, white, black, modes *ccittTable) {
+	a0 := -1
+	colour := ccittColourWhite
+	for a0 < width {
+		a1 := ccittNextChange(cur, a0, width)
+		a2 := ccittNextChange(cur, a1, width)
+		b1, b2 := ccittReferenceChanges(ref, a0, colour, width)
+
+		// Pass mode.
+		if b2 < a1 {
+			w.writeCode(modes.encode[ccittModePass])
+			a0 = b2
+			continue
+		}
+
+		// Vertical mode.
+		d := a1 - b1
+		if (d >= -3) && (d <= 3) {
+			w.writeCode(modes.encode[ccittModeVertical+d])
+			a0 = a1
+			colour ^= 1
+			continue
+		}
+
+		// Horizontal mode.
+		w.writeCode(modes.encode[ccittModeHorizontal])
+		ccittWriteRun(w, a1-max(a0, 0), colour, white, black)
+		ccittWriteRun(w, a2-a1, colour^1, white, black)
+		a0 = a2
+	}
+}
+
+// ccittWriteRun writes a run length of the specified colour.
+func ccittWriteRun(w *ccittBitWriter, run int, colour int, white, black *ccittTable) {
+	t := white
+	if colour == ccittColourBlack {
+		t = black
+	}
+
+	for run >= ccittRunMax {
+		w.writeCode(t.encode[ccittRunMax])
+		run -= ccittRunMax
+	}
+	if run >= ccittRunMakeupX {
+		w.writeCode(t.encode[run/ccittRunMakeupX*ccittRunMakeupX])
+		run %= ccittRunMakeupX
+	}
+	w.writeCode(t.encode[run])
+}
+
+// ccittDecode decodes the specified number of rows. Rows are packed, where
+// the first pixel is the most significant bit and the one bit is black.
+func ccittDecode(data []byte, width uint, height uint, mode byte) (rows [][]byte, err error) {
+	// Every row takes at least one bit of the data.
+	if height > uint(len(data))*bit.BitsPerByte {
+		return nil, errors.New(ErrCcittData)
+	}
+
+	white, black, modes := ccittTables()
+	r := &ccittBitReader{data: data}
+
+	ref := []int{}
+	for y := uint(0); y < height; y++ {
+		var cur []int
+
+		switch mode {
+		case ccittModeMH:
+			cur, err = ccittDecodeRow1D(r, int(width), white, black)
+			r.align()
+
+		case ccittModeG3_1D:
+			r.skipEol()
+			cur, err = ccittDecodeRow1D(r, int(width), white, black)
+
+		case ccittModeG3_2D:
+			r.skipEol()
+			var tag uint32
+			tag, err = r.readBit()
+			if err != nil {
+				return nil, err
+			}
+			if tag == 1 {
+				cur, err = ccittDecodeRow1D(r, int(width), white, black)
+			} else {
+				cur, err = ccittDecodeRow2D(r, ref, int(width), white, black, modes)
+			}
+
+		case ccittModeG4:
+			cur, err = ccittDecodeRow2D(r, ref, int(width), white, black, modes)
+
+		default:
+			return nil, errors.New(ErrCcittMode)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, ccittPackRow(cur, int(width)))
+		ref = cur
+	}
+
+	return rows, nil
+}
+
+// ccittDecodeRow1D decodes a row of run lengths.
+func ccittDecodeRow1D(r *ccittBitReader, width int, white, black *ccittTable) (changes []int, err error) {
+	pos := 0
+	colour := ccittColourWhite
+	for pos < width {
+		var run int
+		run, err = ccittReadRun(r, colour, white, black)
+		if err != nil {
+			return nil, err
+		}
+
+		pos += run
+		if pos > width {
+			return nil, errors.New(ErrCcittRowWidth)
+		}
+		if pos < width {
+			changes = ccittAddChange(changes, pos)
+		}
+		colour ^= 1
+	}
+
+	return changes, nil
+}
+
+// ccittDecodeRow2D decodes a row relative to the reference row.
+func ccittDecodeRow2D(r *ccittBitReader, ref []int, width int, white, black, modes *ccittTable) (changes []int, err error) {
+	a0 := -1
+	colour := ccittColourWhite
+	for a0 < width {
+		var mode int
+		mode, err = r.readCode(modes)
+		if err != nil {
+			return nil, err
+		}
+		b1, b2 := ccittReferenceChanges(ref, a0, colour, width)
+		start := max(a0, 0)
+
+		switch mode {
+		case ccittModePass:
+			a0 = b2
+
+		case ccittModeHorizontal:
+			var run1, run2 int
+			run1, err = ccittReadRun(r, colour, white, black)
+			if err != nil {
+				return nil, err
+			}
+			run2, err = ccittReadRun(r, colour^1, white, black)
+			if err != nil {
+				return nil, err
+			}
+			a1 := start + run1
+			a0 = a1 + run2
+			if a0 > width {
+				return nil, errors.New(ErrCcittRowWidth)
+			}
+			if a1 < width {
+				changes = ccittAddChange(changes, a1)
+			}
+			if a0 < width {
+				changes = ccittAddChange(changes, a0)
+			}
+
+		default:
+			a1 := b1 + mode - ccittModeVertical
+			if (a1 < start) || (a1 > width) {
+				return nil, errors.New(ErrCcittRowWidth)
+			}
+			if a1 < width {
+				changes = ccittAddChange(changes, a1)
+			}
+			a0 = a1
+			colour ^= 1
+		}
+	}
+
+	return changes, nil
+}
+
+// ccittReadRun reads a run length of the specified colour.
+func ccittReadRun(r *ccittBitReader, colour int, white, black *ccittTable) (run int, err error) {
+	t := white
+	if colour == ccittColourBlack {
+		t = black
+	}
+
+	for {
+		var n int
+		n, err = r.readCode(t)
+		if err != nil {
+			return 0, err
+		}
+		run += n
+		if run < 0 {
+			return 0, errors.New(ErrCcittRun)
+		}
+		if n < ccittRunMakeupX {
+			return run, nil
+		}
+	}
+}
+
+// ccittAddChange adds a changing element to the list. Two changing elements
+// at the same position cancel each other.
+func ccittAddChange(changes []int, pos int) []int {
+	if (len(changes) > 0) && (changes[len(changes)-1] == pos) {
+		return changes[:len(changes)-1]
+	}
+	return append(changes, pos)
+}
+
+// ccittRowChanges returns positions of the changing elements of a packed row.
+func ccittRowChanges(row []byte, width int) (changes []int) {
+	colour := byte(ccittColourWhite)
+	for x := 0; x < width; x++ {
+		c := (row[x/bit.BitsPerByte] >> (7 - x%bit.BitsPerByte)) & 1
+		if c != colour {
+			changes = append(changes, x)
+			colour = c
+		}
+	}
+	return changes
+}
+
+// ccittPackRow packs a row described by the changing elements.
+func ccittPackRow(changes []int, width int) (row []byte) {
+	row = make([]byte, (width+bit.BitsPerByte-1)/bit.BitsPerByte)
+	for k := 0; k < len(changes); k += 2 {
+		end := width
+		if k+1 < len(changes) {
+			end = changes[k+1]
+		}
+		for x := changes[k]; x < end; x++ {
+			row[x/bit.BitsPerByte] |= 0x80 >> (x % bit.BitsPerByte)
+		}
+	}
+	return row
+}
+
+// ccittNextChange returns the first changing element after the position.
+func ccittNextChange(changes []int, pos int, width int) int {
+	k := sort.SearchInts(changes, pos+1)
+	if k < len(changes) {
+		return changes[k]
+	}
+	return width
+}
+
+// ccittReferenceChanges returns the 'b1' and 'b2' changing elements of the
+// reference row.
+func ccittReferenceChanges(ref []int, a0 int, colour int, width int) (b1, b2 int) {
+	k := sort.SearchInts(ref, a0+1)
+
+	// Even changing elements change the colour to black, odd – to white.
+	if k%2 != colour {
+		k++
+	}
+
+	b1, b2 = width, width
+	if k < len(ref) {
+		b1 = ref[k]
+	}
+	if k+1 < len(ref) {
+		b2 = ref[k+1]
+	}
+	return b1, b2
+}
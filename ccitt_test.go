@@ -0,0 +1,121 @@
+package sbm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ccittTables(t *testing.T) {
+
+	var tst = tester.New(t)
+
+	// Codes of every table must be prefix-free, otherwise decoding is
+	// ambiguous.
+	lists := []map[int]string{
+		mergeCcittCodeLists(ccittWhiteCodes, ccittExtendedCodes),
+		mergeCcittCodeLists(ccittBlackCodes, ccittExtendedCodes),
+		ccittModeCodes,
+	}
+	for _, list := range lists {
+		for n1, c1 := range list {
+			for n2, c2 := range list {
+				if n1 == n2 {
+					continue
+				}
+				tst.MustBeEqual(strings.HasPrefix(c2, c1), false)
+			}
+		}
+	}
+
+	// Every run length has a terminating code.
+	white, black, _ := ccittTables()
+	for run := 0; run < ccittRunMakeupX; run++ {
+		_, ok := white.encode[run]
+		tst.MustBeEqual(ok, true)
+		_, ok = black.encode[run]
+		tst.MustBeEqual(ok, true)
+	}
+}
+
+func Test_ccittEncode(t *testing.T) {
+
+	var data []byte
+	var err error
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. A white row in G4: V0 followed by EOFB.
+	data, err = ccittEncode([][]byte{{0x00}}, 8, ccittModeG4)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(data, []byte{0x80, 0x08, 0x00, 0x80})
+
+	// Test #2. A row of 3 white and 5 black pixels in MH.
+	data, err = ccittEncode([][]byte{{0x1F}}, 8, ccittModeMH)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(data, []byte{0x83})
+
+	// Test #3. Unknown mode.
+	_, err = ccittEncode([][]byte{{0x00}}, 8, 0)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrCcittMode)
+}
+
+func Test_ccittDecode(t *testing.T) {
+
+	var err error
+	var rows [][]byte
+	var rowsExpected [][]byte
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Rows with long runs, short runs and runs at the edges.
+	const width = 3000
+	rowsExpected = make([][]byte, 0)
+	for y := 0; y < 12; y++ {
+		row := make([]byte, (width+7)/8)
+		for x := 0; x < width; x++ {
+			if ((x*(y+1))/(3+y*y))%2 == 1 || (y == 5 && x > 2700) || (y == 6 && x < 2600) {
+				row[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		rowsExpected = append(rowsExpected, row)
+	}
+
+	// Test #1. Round trip in all the modes.
+	for _, mode := range []byte{ccittModeMH, ccittModeG3_1D, ccittModeG3_2D, ccittModeG4} {
+		var data []byte
+		data, err = ccittEncode(rowsExpected, width, mode)
+		tst.MustBeNoError(err)
+
+		rows, err = ccittDecode(data, width, uint(len(rowsExpected)), mode)
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(rows, rowsExpected)
+	}
+
+	// Test #2. Truncated data.
+	data, err := ccittEncode(rowsExpected, width, ccittModeG4)
+	tst.MustBeNoError(err)
+	_, err = ccittDecode(data[:len(data)/2], width, uint(len(rowsExpected)), ccittModeG4)
+	tst.MustBeAnError(err)
+
+	// Test #3. Row is longer than the width.
+	data, err = ccittEncode([][]byte{{0x00, 0x00}}, 16, ccittModeG3_1D)
+	tst.MustBeNoError(err)
+	_, err = ccittDecode(data, 8, 1, ccittModeG3_1D)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrCcittRowWidth)
+}
+
+func mergeCcittCodeLists(lists ...map[int]string) (result map[int]string) {
+	result = make(map[int]string)
+	for _, list := range lists {
+		for n, c := range list {
+			result[n] = c
+		}
+	}
+	return result
+}
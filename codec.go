@@ -0,0 +1,220 @@
+package sbm
+
+import (
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Maximum size of a buffer returned to the pool of encoding buffers. Larger
+// buffers are left to the garbage collector.
+const EncodeBufferPoolMaxSize = 64 * 1024
+
+// encodeBufferPool is a pool of encoding buffers.
+var encodeBufferPool = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
+}
+
+// NewDecoder creates a decoder reading the stream with the options.
+func NewDecoder(reader io.Reader, opts DecodeOptions) (d *Decoder) {
+	return &Decoder{
+		opts:   opts,
+		reader: countingReader{reader: reader},
+	}
+}
+
+// Reset makes the decoder read another stream keeping its buffers.
+func (d *Decoder) Reset(reader io.Reader) {
+	d.reader = countingReader{reader: reader}
+}
+
+// Decode reads the next SBM object from the stream. When the stream ends
+// before the object, io.EOF is returned. When the stream ends inside the
+// object, the error wraps io.ErrUnexpectedEOF.
+func (d *Decoder) Decode() (sbm *Sbm, err error) {
+	sbm = new(Sbm)
+
+	err = d.DecodeInto(sbm)
+	if err != nil {
+		return nil, err
+	}
+
+	return sbm, nil
+}
+
+// DecodeInto reads the next SBM object from the stream into an existing
+// object. Storage of the pixel array of the object is reused when it is large
+// enough. On error the object is left in an undefined state. Errors are the
+// same as of the Decode method.
+func (d *Decoder) DecodeInto(sbm *Sbm) (err error) {
+	bits := sbm.pixelArray.data.bits[:0]
+	bytesArray := sbm.pixelArray.data.bytes[:0]
+	*sbm = Sbm{}
+	start := d.reader.offset
+
+	// Read the top headers.
+	err = sbm.parseTopHeaders(d.readLine, d.opts)
+	if err != nil {
+		if (d.reader.offset == start) && errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+
+	// Read the binary array of bits and the separator.
+	err = d.readArrayData(sbm, bits, bytesArray)
+	if err != nil {
+		return err
+	}
+
+	// Read the bottom headers.
+	err = sbm.parseBottomHeaders(d.readLine)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readLine reads a header line into the buffer of the decoder. The line is
+// valid until the next reading.
+func (d *Decoder) readLine(section ParseSection, name string, number int) (hl headerLine, err error) {
+	hl = headerLine{
+		section: section,
+		name:    name,
+		number:  number,
+		offset:  d.reader.offset,
+	}
+
+	d.line = d.line[:0]
+	for {
+		_, err = io.ReadFull(&d.reader, d.scratch[:1])
+		if err != nil {
+			hl.raw = d.line
+			return hl, hl.error(unexpectedEOF(err))
+		}
+		d.line = append(d.line, d.scratch[0])
+
+		n := len(d.line)
+		if (d.scratch[0] == LF) && (n >= 2) && (d.line[n-2] == CR) {
+			hl.raw = d.line
+			return hl, nil
+		}
+		if (d.opts.MaxHeaderLineLength != 0) && (uint(n) >= d.opts.MaxHeaderLineLength) {
+			hl.raw = d.line
+			return hl, hl.error(errorLineLength(d.opts.MaxHeaderLineLength))
+		}
+	}
+}
+
+// readArrayData reads the pixel array and the separator into the storage
+// specified.
+func (d *Decoder) readArrayData(sbm *Sbm, bits []bit.Bit, bytesArray []byte) (err error) {
+	offset := d.reader.offset
+
+	readBytesSize := sbm.arraySize()
+	if readBytesSize > math.MaxInt {
+		return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, ErrorOverflow)
+	}
+
+	// Read the Array by chunks.
+	for uint(len(bytesArray)) < readBytesSize {
+		n := int(min(readBytesSize-uint(len(bytesArray)), ArrayChunkSize))
+		bytesArray = slices.Grow(bytesArray, n)
+
+		var m int
+		m, err = io.ReadFull(&d.reader, bytesArray[len(bytesArray):len(bytesArray)+n])
+		bytesArray = bytesArray[:len(bytesArray)+m]
+		if err != nil {
+			return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, unexpectedEOF(err))
+		}
+	}
+
+	// Read the Separator.
+	offset = d.reader.offset
+	var m int
+	m, err = io.ReadFull(&d.reader, d.scratch[:])
+	if err != nil {
+		return newParseError(ParseSectionSeparator, "", LineNumberArray, offset, d.scratch[:m], unexpectedEOF(err))
+	}
+	if (d.scratch[0] != CR) || (d.scratch[1] != LF) {
+		return newParseError(ParseSectionSeparator, "", LineNumberArray, offset, d.scratch[:], ErrorBadSeparator)
+	}
+
+	// Save the Array, clearing the unused bits of the last byte.
+	area := sbm.pixelArray.metaData.area
+	if area%bit.BitsPerByte != 0 {
+		bytesArray[len(bytesArray)-1] &= 1<<(area%bit.BitsPerByte) - 1
+	}
+	bits = slices.Grow(bits, int(area))
+	for i := uint(0); i < area; i++ {
+		bits = append(bits, (bytesArray[i/bit.BitsPerByte]>>(i%bit.BitsPerByte))&1 == 1)
+	}
+	sbm.pixelArray.data.bits = bits
+	sbm.pixelArray.data.bytes = bytesArray
+
+	return nil
+}
+
+// unexpectedEOF replaces the end of the stream inside an object with the
+// io.ErrUnexpectedEOF error.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// NewEncoder creates an encoder writing into the stream with the options.
+func NewEncoder(writer io.Writer, opts EncodeOptions) (e *Encoder) {
+	return &Encoder{
+		writer: writer,
+		opts:   opts,
+	}
+}
+
+// Reset makes the encoder write into another stream.
+func (e *Encoder) Reset(writer io.Writer) {
+	e.writer = writer
+}
+
+// Encode writes an SBM object into the stream.
+func (e *Encoder) Encode(sbm *Sbm) (err error) {
+	if e.opts.Split != nil {
+		tmp := *sbm
+		tmp.split = *e.opts.Split
+		err = tmp.fillRandomHeaders()
+		if err != nil {
+			return err
+		}
+		sbm = &tmp
+	}
+
+	bufPtr := encodeBufferPool.Get().(*[]byte)
+	buf := (*bufPtr)[:0]
+
+	buf = sbm.appendTopHeaders(buf)
+	buf = append(buf, sbm.pixelArray.data.bytes...)
+	buf = append(buf, NL...)
+	buf = sbm.appendBottomHeaders(buf)
+
+	_, err = e.writer.Write(buf)
+
+	if cap(buf) <= EncodeBufferPoolMaxSize {
+		*bufPtr = buf
+		encodeBufferPool.Put(bufPtr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Decoder(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm1, err := NewFromBitsArray(diagonalLineBits(13, 7), 13, 7)
+	tst.MustBeNoError(err)
+	sbm2, err := NewFromBitsArray(diagonalLineBits(5, 3), 5, 3)
+	tst.MustBeNoError(err)
+	buffer := new(bytes.Buffer)
+	tst.MustBeNoError(sbm1.Write(buffer))
+	tst.MustBeNoError(sbm2.Write(buffer))
+	data := buffer.Bytes()
+
+	// Test #1. Several objects in the stream.
+	d := NewDecoder(bytes.NewReader(data), DecodeOptions{})
+	sbm, err = d.Decode()
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(*sbm, *sbm1)
+	sbm, err = d.Decode()
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(*sbm, *sbm2)
+	_, err = d.Decode()
+	tst.MustBeEqual(err, io.EOF)
+
+	// Test #2. Storage is reused.
+	d.Reset(bytes.NewReader(data))
+	sbm = new(Sbm)
+	tst.MustBeNoError(d.DecodeInto(sbm))
+	bitsPtr, bytesPtr := &sbm.pixelArray.data.bits[0], &sbm.pixelArray.data.bytes[0]
+	tst.MustBeNoError(d.DecodeInto(sbm))
+	tst.MustBeEqual(*sbm, *sbm2)
+	tst.MustBeEqual(&sbm.pixelArray.data.bits[0] == bitsPtr, true)
+	tst.MustBeEqual(&sbm.pixelArray.data.bytes[0] == bytesPtr, true)
+
+	// Test #3. Limits.
+	d = NewDecoder(bytes.NewReader(data), DecodeOptions{MaxHeaderLineLength: 16})
+	_, err = d.Decode()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	d = NewDecoder(bytes.NewReader(data), DecodeOptions{MaxArea: 90})
+	_, err = d.Decode()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+
+	// Test #4. Damaged header.
+	damaged := bytes.Clone(data)
+	damaged[bytes.Index(damaged, []byte("AREA"))+len("AREA 91 (")] = 'x'
+	_, err = NewDecoder(bytes.NewReader(damaged), DecodeOptions{}).Decode()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorHeaderSyntax), true)
+
+	// Test #5. Bad separator.
+	damaged = bytes.Clone(data)
+	damaged[len(sbm1.appendTopHeaders(nil))+len(sbm1.GetArrayBytes())] = 'x'
+	_, err = NewDecoder(bytes.NewReader(damaged), DecodeOptions{}).Decode()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorBadSeparator), true)
+
+	// Test #6. Truncated array.
+	_, err = NewDecoder(bytes.NewReader(data[:len(sbm1.appendTopHeaders(nil))+5]), DecodeOptions{}).Decode()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.ErrUnexpectedEOF), true)
+}
+
+func Test_Encoder(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var bufferReference *bytes.Buffer
+	var err error
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm1, err := NewFromBitsArray(diagonalLineBits(13, 7), 13, 7)
+	tst.MustBeNoError(err)
+	sbm2, err := NewFromBitsArray(diagonalLineBits(5, 3), 5, 3)
+	tst.MustBeNoError(err)
+
+	// Test #1. Output is the same as of the Write method.
+	bufferReference = new(bytes.Buffer)
+	tst.MustBeNoError(sbm1.Write(bufferReference))
+	tst.MustBeNoError(sbm2.Write(bufferReference))
+	buffer = new(bytes.Buffer)
+	e := NewEncoder(buffer, EncodeOptions{})
+	tst.MustBeNoError(e.Encode(sbm1))
+	tst.MustBeNoError(e.Encode(sbm2))
+	tst.MustBeEqual(buffer.Bytes(), bufferReference.Bytes())
+
+	// Test #2. Split options.
+	bufferReference = new(bytes.Buffer)
+	tst.MustBeNoError(sbm2.WriteWithSplitOptions(bufferReference, SplitOptions{Mode: SplitModeCanonical}))
+	buffer = new(bytes.Buffer)
+	e = NewEncoder(io.Discard, EncodeOptions{Split: &SplitOptions{Mode: SplitModeCanonical}})
+	e.Reset(buffer)
+	tst.MustBeNoError(e.Encode(sbm2))
+	tst.MustBeEqual(buffer.Bytes(), bufferReference.Bytes())
+	err = NewEncoder(buffer, EncodeOptions{Split: &SplitOptions{Mode: 99}}).Encode(sbm2)
+	tst.MustBeAnError(err)
+}
+
+func Benchmark_NewFromStream(b *testing.B) {
+	source, err := NewFromBitsArray(diagonalLineBits(32, 32), 32, 32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	buffer := new(bytes.Buffer)
+	err = source.Write(buffer)
+	if err != nil {
+		b.Fatal(err)
+	}
+	reader := bytes.NewReader(buffer.Bytes())
+
+	b.ReportAllocs()
+	for b.Loop() {
+		reader.Reset(buffer.Bytes())
+		_, err = NewFromStream(reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Decoder_DecodeInto(b *testing.B) {
+	source, err := NewFromBitsArray(diagonalLineBits(32, 32), 32, 32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	buffer := new(bytes.Buffer)
+	err = source.Write(buffer)
+	if err != nil {
+		b.Fatal(err)
+	}
+	reader := bytes.NewReader(buffer.Bytes())
+	d := NewDecoder(reader, DecodeOptions{})
+	sbm := new(Sbm)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		reader.Reset(buffer.Bytes())
+		d.Reset(reader)
+		err = d.DecodeInto(sbm)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Write(b *testing.B) {
+	sbm, err := NewFromBitsArray(diagonalLineBits(32, 32), 32, 32)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		err = sbm.Write(io.Discard)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Encoder_Encode(b *testing.B) {
+	sbm, err := NewFromBitsArray(diagonalLineBits(32, 32), 32, 32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	e := NewEncoder(io.Discard, EncodeOptions{})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		err = e.Encode(sbm)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package sbm
+
+import (
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Display buffer parameters.
+const (
+	DisplayPageHeight = 8
+)
+
+// Errors.
+const (
+	ErrDisplayBufferSize = "display buffer size mismatch"
+)
+
+// WritePageBuffer writes an SBM object into the stream as a page-major buffer
+// used by SSD1306 and SH1106 OLED controllers. Every page is a band of eight
+// rows, a byte is a column of the band having the top pixel in the least
+// significant bit. When the height is not a multiple of eight, the last page
+// is padded with white pixels.
+func (sbm *Sbm) WritePageBuffer(writer io.Writer, opts DisplayOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	pages := (height + DisplayPageHeight - 1) / DisplayPageHeight
+	buf := make([]byte, pages*width)
+	for y := uint(0); y < pages*DisplayPageHeight; y++ {
+		for x := uint(0); x < width; x++ {
+			black := (y < height) && (sbm.getPixel(x, y) == bit.Zero)
+			if black == opts.BlackIsOne {
+				buf[(y/DisplayPageHeight)*width+x] |= 1 << (y % DisplayPageHeight)
+			}
+		}
+	}
+
+	_, err = writer.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewFromPageBuffer reads an SBM object of the specified size from the stream
+// containing a page-major buffer of an OLED controller.
+func NewFromPageBuffer(reader io.Reader, width uint, height uint, opts DisplayOptions) (sbm *Sbm, err error) {
+	if (width == 0) || (height == 0) {
+		return nil, ErrorDimension
+	}
+
+	pages := (height + DisplayPageHeight - 1) / DisplayPageHeight
+	buf := make([]byte, pages*width)
+	_, err = io.ReadFull(reader, buf)
+	if err != nil {
+		return nil, errors.New(ErrDisplayBufferSize)
+	}
+
+	bits := make([]bit.Bit, 0, width*height)
+	for y := uint(0); y < height; y++ {
+		for x := uint(0); x < width; x++ {
+			one := buf[(y/DisplayPageHeight)*width+x]&(1<<(y%DisplayPageHeight)) != 0
+			bits = append(bits, bit.Bit(one != opts.BlackIsOne))
+		}
+	}
+
+	return NewFromBitsArray(bits, width, height)
+}
+
+// WriteRowBuffer writes an SBM object into the stream as a row-major buffer
+// used by e-paper controllers. The first pixel of a row is the most
+// significant bit, every row starts with a new byte. Unused bits of a row are
+// white.
+func (sbm *Sbm) WriteRowBuffer(writer io.Writer, opts DisplayOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	var padding byte
+	if !opts.BlackIsOne && (width%bit.BitsPerByte != 0) {
+		padding = 0xFF >> (width % bit.BitsPerByte)
+	}
+
+	for y := uint(0); y < height; y++ {
+		row := sbm.getRowBytesMSB(y, opts.BlackIsOne)
+		row[len(row)-1] |= padding
+
+		_, err = writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewFromRowBuffer reads an SBM object of the specified size from the stream
+// containing a row-major buffer of an e-paper controller.
+func NewFromRowBuffer(reader io.Reader, width uint, height uint, opts DisplayOptions) (sbm *Sbm, err error) {
+	if (width == 0) || (height == 0) {
+		return nil, ErrorDimension
+	}
+
+	rowSize := (width + bit.BitsPerByte - 1) / bit.BitsPerByte
+	buf := make([]byte, rowSize*height)
+	_, err = io.ReadFull(reader, buf)
+	if err != nil {
+		return nil, errors.New(ErrDisplayBufferSize)
+	}
+
+	bits := make([]bit.Bit, 0, width*height)
+	for y := uint(0); y < height; y++ {
+		bits = appendRowBitsMSB(bits, buf[y*rowSize:], width, opts.BlackIsOne)
+	}
+
+	return NewFromBitsArray(bits, width, height)
+}
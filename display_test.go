@@ -0,0 +1,100 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WritePageBuffer(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var sbm2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// 2x9 image, the first column is black at the top and at the bottom.
+	bits := make([]bit.Bit, 18)
+	for i := range bits {
+		bits[i] = bit.One
+	}
+	bits[0], bits[16], bits[3] = bit.Zero, bit.Zero, bit.Zero
+	sbm, err = NewFromBitsArray(bits, 2, 9)
+	tst.MustBeNoError(err)
+
+	// Test #1. Black is lit.
+	buffer = new(bytes.Buffer)
+	err = sbm.WritePageBuffer(buffer, DisplayOptions{BlackIsOne: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{0x01, 0x02, 0x01, 0x00})
+
+	sbm2, err = NewFromPageBuffer(buffer, 2, 9, DisplayOptions{BlackIsOne: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #2. White is one, padding is white.
+	buffer = new(bytes.Buffer)
+	err = sbm.WritePageBuffer(buffer, DisplayOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{0xFE, 0xFD, 0xFE, 0xFF})
+
+	sbm2, err = NewFromPageBuffer(buffer, 2, 9, DisplayOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #3. Buffer is too short.
+	_, err = NewFromPageBuffer(bytes.NewReader([]byte{0, 0, 0}), 2, 9, DisplayOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrDisplayBufferSize)
+}
+
+func Test_WriteRowBuffer(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var sbm2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.One,
+			bit.One, bit.Zero, bit.Zero,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. White is one, padding is white.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteRowBuffer(buffer, DisplayOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{0x7F, 0x9F})
+
+	sbm2, err = NewFromRowBuffer(buffer, 3, 2, DisplayOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #2. Black is one.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteRowBuffer(buffer, DisplayOptions{BlackIsOne: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{0x80, 0x60})
+
+	sbm2, err = NewFromRowBuffer(buffer, 3, 2, DisplayOptions{BlackIsOne: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #3. Bad size.
+	_, err = NewFromRowBuffer(bytes.NewReader(nil), 0, 2, DisplayOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrDimension)
+}
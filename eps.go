@@ -0,0 +1,139 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// EPS file parameters.
+const (
+	EpsHeader        = "%!PS-Adobe-3.0 EPSF-3.0\n"
+	EpsCreator       = "SBM"
+	EpsPointsPerInch = 72
+	EpsDpiDefault    = EpsPointsPerInch
+	EpsLineLength    = 72
+	EpsRunLengthEOD  = 128
+	EpsASCIIHexEOD   = ">"
+	EpsASCII85EOD    = "~>"
+	EpsImagePolarity = "false"
+	EpsTrailer       = "%%EOF\n"
+	EpsLanguageLevel = 2
+)
+
+// Errors.
+const (
+	ErrEpsEncoding = "EPS encoding is not supported"
+)
+
+// WriteEPS writes an SBM object into the stream as an Encapsulated PostScript
+// file. Bit values of SBM and of the PostScript image are the same: zero is
+// black and one is white. The 'imagemask' operator paints the zero bits.
+func (sbm *Sbm) WriteEPS(writer io.Writer, opts EpsOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	dpi := opts.DPI
+	if dpi == 0 {
+		dpi = EpsDpiDefault
+	}
+	boxWidth := float64(width) * EpsPointsPerInch / float64(dpi)
+	boxHeight := float64(height) * EpsPointsPerInch / float64(dpi)
+
+	// 1. Data.
+	data := sbm.epsImageData()
+	var filters string
+	if opts.RunLength {
+		data = append(packBitsEncode(data), EpsRunLengthEOD)
+		filters = " /RunLengthDecode filter"
+	}
+
+	var encoded []byte
+	switch opts.Encoding {
+	case 0, EpsEncodingASCIIHex:
+		encoded = []byte(hex.EncodeToString(data))
+		encoded = append(epsSplitLines(encoded), EpsASCIIHexEOD...)
+		filters = " /ASCIIHexDecode filter" + filters
+
+	case EpsEncodingASCII85:
+		encoded = make([]byte, ascii85.MaxEncodedLen(len(data)))
+		encoded = encoded[:ascii85.Encode(encoded, data)]
+		encoded = append(epsSplitLines(encoded), EpsASCII85EOD...)
+		filters = " /ASCII85Decode filter" + filters
+
+	default:
+		return errors.New(ErrEpsEncoding)
+	}
+
+	// 2. Program.
+	buf := new(bytes.Buffer)
+	buf.WriteString(EpsHeader)
+	fmt.Fprintf(buf, "%%%%BoundingBox: 0 0 %d %d\n", int(math.Ceil(boxWidth)), int(math.Ceil(boxHeight)))
+	fmt.Fprintf(buf, "%%%%HiResBoundingBox: 0 0 %s %s\n", pdfNumber(boxWidth), pdfNumber(boxHeight))
+	fmt.Fprintf(buf, "%%%%Creator: %s\n", EpsCreator)
+	fmt.Fprintf(buf, "%%%%LanguageLevel: %d\n", EpsLanguageLevel)
+	buf.WriteString("%%EndComments\n")
+	buf.WriteString("save\n")
+	fmt.Fprintf(buf, "%s %s scale\n", pdfNumber(boxWidth), pdfNumber(boxHeight))
+
+	matrix := fmt.Sprintf("[%d 0 0 -%d 0 %d]", width, height, height)
+	if opts.ImageMask {
+		fmt.Fprintf(buf, "0 setgray\n%d %d %s %s currentfile%s imagemask\n",
+			width, height, EpsImagePolarity, matrix, filters)
+	} else {
+		fmt.Fprintf(buf, "/DeviceGray setcolorspace\n%d %d 1 %s currentfile%s image\n",
+			width, height, matrix, filters)
+	}
+	buf.Write(encoded)
+	buf.WriteString("\nrestore\nshowpage\n")
+	buf.WriteString(EpsTrailer)
+
+	_, err = writer.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// epsImageData returns rows of the image, where each row starts at a byte
+// boundary and the first pixel is the most significant bit. When rows of SBM
+// are aligned, packed SBM bytes are used with the reversed bit order.
+func (sbm *Sbm) epsImageData() (data []byte) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+
+	if width%bit.BitsPerByte == 0 {
+		data = make([]byte, len(sbm.pixelArray.data.bytes))
+		for i, b := range sbm.pixelArray.data.bytes {
+			data[i] = bits.Reverse8(b)
+		}
+		return data
+	}
+
+	data = make([]byte, 0, height*((width+bit.BitsPerByte-1)/bit.BitsPerByte))
+	for y := uint(0); y < height; y++ {
+		data = append(data, sbm.getRowBytesMSB(y, false)...)
+	}
+	return data
+}
+
+// epsSplitLines splits the encoded data into lines of limited length.
+func epsSplitLines(encoded []byte) (lines []byte) {
+	lines = make([]byte, 0, len(encoded)+len(encoded)/EpsLineLength+1)
+	for len(encoded) > EpsLineLength {
+		lines = append(lines, encoded[:EpsLineLength]...)
+		lines = append(lines, '\n')
+		encoded = encoded[EpsLineLength:]
+	}
+	return append(lines, encoded...)
+}
@@ -0,0 +1,87 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteEPS(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. ASCIIHex encoding.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEPS(buffer, EpsOptions{DPI: 144})
+	tst.MustBeNoError(err)
+	eps := buffer.String()
+	tst.MustBeEqual(strings.HasPrefix(eps, EpsHeader), true)
+	tst.MustBeEqual(strings.Contains(eps, "\n%%BoundingBox: 0 0 2 1\n"), true)
+	tst.MustBeEqual(strings.Contains(eps, "\n%%HiResBoundingBox: 0 0 1.5 1\n"), true)
+	tst.MustBeEqual(strings.Contains(eps, "3 2 1 [3 0 0 -2 0 2] currentfile /ASCIIHexDecode filter image\na020>\n"), true)
+	tst.MustBeEqual(strings.HasSuffix(eps, EpsTrailer), true)
+
+	// Test #2. Image mask, ASCII85 and RunLength encodings.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEPS(buffer, EpsOptions{ImageMask: true, Encoding: EpsEncodingASCII85, RunLength: true})
+	tst.MustBeNoError(err)
+	eps = buffer.String()
+	tst.MustBeEqual(strings.Contains(eps, "\n%%BoundingBox: 0 0 3 2\n"), true)
+	prefix := "3 2 false [3 0 0 -2 0 2] currentfile /ASCII85Decode filter /RunLengthDecode filter imagemask\n"
+	start := strings.Index(eps, prefix)
+	tst.MustBeDifferent(start, -1)
+	encoded := eps[start+len(prefix) : strings.Index(eps, EpsASCII85EOD)]
+	decoded := make([]byte, 100)
+	n, _, err := ascii85.Decode(decoded, []byte(encoded), true)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(decoded[:n], []byte{0x01, 0xA0, 0x20, EpsRunLengthEOD})
+
+	// Test #3. Aligned rows use the packed SBM bytes.
+	bits := make([]bit.Bit, 0, 16*100)
+	for i := 0; i < 16*100; i++ {
+		bits = append(bits, bit.Bit(i%3 == 0))
+	}
+	sbm, err = NewFromBitsArray(bits, 16, 100)
+	tst.MustBeNoError(err)
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEPS(buffer, EpsOptions{})
+	tst.MustBeNoError(err)
+	eps = buffer.String()
+	start = strings.Index(eps, "image\n") + len("image\n")
+	encoded = strings.ReplaceAll(eps[start:strings.Index(eps, EpsASCIIHexEOD)], "\n", "")
+	data, err := hex.DecodeString(encoded)
+	tst.MustBeNoError(err)
+	expected := []byte{}
+	for y := uint(0); y < 100; y++ {
+		expected = append(expected, sbm.getRowBytesMSB(y, false)...)
+	}
+	tst.MustBeEqual(data, expected)
+	for _, line := range strings.Split(eps, "\n") {
+		tst.MustBeEqual(len(line) <= EpsLineLength, true)
+	}
+
+	// Test #4. Unknown encoding.
+	err = sbm.WriteEPS(new(bytes.Buffer), EpsOptions{Encoding: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrEpsEncoding)
+}
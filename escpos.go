@@ -0,0 +1,139 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// ESC/POS command parameters.
+const (
+	EscPosESC                   = 0x1B
+	EscPosGS                    = 0x1D
+	EscPosLF                    = 0x0A
+	EscPosBandHeightDefault     = 256
+	EscPosRasterDimensionMax    = 0xFFFF
+	EscPosColumnLineSpacing     = 24
+	EscPosColumnDensity8        = 1
+	EscPosColumnDensity24       = 33
+	EscPosColumnBandHeight8     = 8
+	EscPosColumnBandHeight24    = 24
+	EscPosRasterModeNormal      = 0
+	escPosCommandRaster         = "\x1Dv0"
+	escPosCommandColumn         = "\x1B*"
+	escPosCommandLineSpacing    = "\x1B3"
+	escPosCommandLineSpacingStd = "\x1B2"
+)
+
+// Errors.
+const (
+	ErrEscPosMode     = "ESC/POS mode is not supported"
+	ErrEscPosTooWide  = "image is wider than the printer"
+	ErrEscPosTooLarge = "image is too large for ESC/POS"
+)
+
+// WriteEscPos writes an SBM object into the stream as ESC/POS bit image
+// commands. In ESC/POS the one bit is a black dot, the first dot is the most
+// significant bit.
+func (sbm *Sbm) WriteEscPos(writer io.Writer, opts EscPosOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	// Padding.
+	lineWidth, offset := width, uint(0)
+	if opts.PrinterWidth != 0 {
+		if width > opts.PrinterWidth {
+			return errors.New(ErrEscPosTooWide)
+		}
+		lineWidth = opts.PrinterWidth
+		if opts.Center {
+			offset = (opts.PrinterWidth - width) / 2
+		}
+	}
+	if lineWidth > EscPosRasterDimensionMax {
+		return errors.New(ErrEscPosTooLarge)
+	}
+
+	buf := new(bytes.Buffer)
+	switch opts.Mode {
+	case EscPosModeRaster:
+		sbm.writeEscPosRaster(buf, lineWidth, offset, opts.BandHeight)
+	case EscPosModeColumn8:
+		sbm.writeEscPosColumns(buf, lineWidth, offset, EscPosColumnBandHeight8, EscPosColumnDensity8)
+	case EscPosModeColumn24:
+		sbm.writeEscPosColumns(buf, lineWidth, offset, EscPosColumnBandHeight24, EscPosColumnDensity24)
+	default:
+		return errors.New(ErrEscPosMode)
+	}
+
+	_, err = writer.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeEscPosRaster writes the image as 'GS v 0' commands, each having a band
+// of rows.
+func (sbm *Sbm) writeEscPosRaster(buf *bytes.Buffer, lineWidth uint, offset uint, bandHeight uint) {
+	height := sbm.pixelArray.metaData.height
+	if (bandHeight == 0) || (bandHeight > EscPosRasterDimensionMax) {
+		bandHeight = EscPosBandHeightDefault
+	}
+	rowSize := (lineWidth + bit.BitsPerByte - 1) / bit.BitsPerByte
+
+	for y0 := uint(0); y0 < height; y0 += bandHeight {
+		rows := min(bandHeight, height-y0)
+		buf.WriteString(escPosCommandRaster)
+		buf.WriteByte(EscPosRasterModeNormal)
+		buf.Write([]byte{byte(rowSize), byte(rowSize >> 8), byte(rows), byte(rows >> 8)})
+
+		for y := y0; y < y0+rows; y++ {
+			row := make([]byte, rowSize)
+			for x := uint(0); x < sbm.pixelArray.metaData.width; x++ {
+				if sbm.getPixel(x, y) == bit.Zero {
+					px := offset + x
+					row[px/bit.BitsPerByte] |= 0x80 >> (px % bit.BitsPerByte)
+				}
+			}
+			buf.Write(row)
+		}
+	}
+}
+
+// writeEscPosColumns writes the image as 'ESC *' commands, each having a band
+// of rows stored as columns of dots. The top dot of a column is the most
+// significant bit of its first byte.
+func (sbm *Sbm) writeEscPosColumns(buf *bytes.Buffer, lineWidth uint, offset uint, bandHeight uint, density byte) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	columnSize := bandHeight / bit.BitsPerByte
+
+	buf.WriteString(escPosCommandLineSpacing)
+	buf.WriteByte(EscPosColumnLineSpacing)
+
+	for y0 := uint(0); y0 < height; y0 += bandHeight {
+		buf.WriteString(escPosCommandColumn)
+		buf.Write([]byte{density, byte(lineWidth), byte(lineWidth >> 8)})
+
+		band := make([]byte, lineWidth*columnSize)
+		for x := uint(0); x < width; x++ {
+			column := band[(offset+x)*columnSize:]
+			for dy := uint(0); (dy < bandHeight) && (y0+dy < height); dy++ {
+				if sbm.getPixel(x, y0+dy) == bit.Zero {
+					column[dy/bit.BitsPerByte] |= 0x80 >> (dy % bit.BitsPerByte)
+				}
+			}
+		}
+		buf.Write(band)
+		buf.WriteByte(EscPosLF)
+	}
+
+	buf.WriteString(escPosCommandLineSpacingStd)
+}
@@ -0,0 +1,78 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteEscPos(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+			bit.One, bit.One, bit.Zero,
+		},
+		3,
+		3,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Raster in bands of two rows.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEscPos(buffer, EscPosOptions{BandHeight: 2})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{
+		0x1D, 'v', '0', 0, 1, 0, 2, 0, 0x40, 0xC0,
+		0x1D, 'v', '0', 0, 1, 0, 1, 0, 0x20,
+	})
+
+	// Test #2. Raster padded to the printer's width and centered.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEscPos(buffer, EscPosOptions{PrinterWidth: 16, Center: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{
+		0x1D, 'v', '0', 0, 2, 0, 3, 0,
+		0x01, 0x00, 0x03, 0x00, 0x00, 0x80,
+	})
+
+	// Test #3. 8-dot columns.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEscPos(buffer, EscPosOptions{Mode: EscPosModeColumn8})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{
+		0x1B, '3', 24,
+		0x1B, '*', 1, 3, 0, 0x40, 0xC0, 0x20, 0x0A,
+		0x1B, '2',
+	})
+
+	// Test #4. 24-dot columns.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteEscPos(buffer, EscPosOptions{Mode: EscPosModeColumn24})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes(), []byte{
+		0x1B, '3', 24,
+		0x1B, '*', 33, 3, 0, 0x40, 0, 0, 0xC0, 0, 0, 0x20, 0, 0, 0x0A,
+		0x1B, '2',
+	})
+
+	// Test #5. Image is wider than the printer.
+	err = sbm.WriteEscPos(new(bytes.Buffer), EscPosOptions{PrinterWidth: 2})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrEscPosTooWide)
+
+	// Test #6. Unknown mode.
+	err = sbm.WriteEscPos(new(bytes.Buffer), EscPosOptions{Mode: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrEscPosMode)
+}
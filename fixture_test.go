@@ -0,0 +1,17 @@
+package sbm
+
+import (
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// diagonalLineBits returns the bits of an image with a diagonal line.
+func diagonalLineBits(width uint, height uint) (bits []bit.Bit) {
+	bits = make([]bit.Bit, width*height)
+	for y := uint(0); y < height; y++ {
+		for x := uint(0); x < width; x++ {
+			bits[y*width+x] = x != y
+		}
+	}
+
+	return bits
+}
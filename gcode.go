@@ -0,0 +1,119 @@
+package sbm
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// G-code parameters.
+const (
+	GcodeDPIDefault      = 254
+	GcodeFeedRateDefault = 1000
+	GcodePowerDefault    = 1000
+	GcodeMillimetresInch = 25.4
+	GcodePrecision       = 3
+)
+
+// WriteGcode writes an SBM object into the stream as G-code for raster laser
+// engraving. Black pixels are burnt row by row, starting with the top row.
+// Every run of black pixels is a burning 'G1' move preceded by a travel 'G0'
+// move, so that blank rows and margins are skipped. The laser is expected to
+// be off during the 'G0' moves, as it is in the laser mode of GRBL.
+func (sbm *Sbm) WriteGcode(writer io.Writer, opts GcodeOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	dpi := opts.DPI
+	if dpi == 0 {
+		dpi = GcodeDPIDefault
+	}
+	feedRate := opts.FeedRate
+	if feedRate == 0 {
+		feedRate = GcodeFeedRateDefault
+	}
+	power := opts.Power
+	if power == 0 {
+		power = GcodePowerDefault
+	}
+	laserOn := "M4"
+	if opts.ConstantPower {
+		laserOn = "M3"
+	}
+	pitch := GcodeMillimetresInch / float64(dpi)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("(SBM %dx%d, %d DPI)\n", width, height, dpi))
+	sb.WriteString("G21\nG90\n")
+	sb.WriteString(fmt.Sprintf("%s S0\n", laserOn))
+
+	feed := fmt.Sprintf(" F%d", feedRate)
+	reverse := false
+	for y := uint(0); y < height; y++ {
+		runs := sbm.getBlackRuns(y)
+		if len(runs) == 0 {
+			continue
+		}
+
+		posY := gcodeNumber(opts.OriginY + float64(height-1-y)*pitch)
+		for i := range runs {
+			x0, x1 := runs[i][0], runs[i][1]
+			if reverse {
+				run := runs[len(runs)-1-i]
+				x0, x1 = run[1], run[0]
+			}
+			sb.WriteString(fmt.Sprintf("G0 X%s Y%s\n", gcodeNumber(opts.OriginX+float64(x0)*pitch), posY))
+			sb.WriteString(fmt.Sprintf("G1 X%s S%d%s\n", gcodeNumber(opts.OriginX+float64(x1)*pitch), power, feed))
+			feed = ""
+		}
+
+		if opts.Serpentine {
+			reverse = !reverse
+		}
+	}
+
+	sb.WriteString("M5\n")
+
+	_, err = writer.Write([]byte(sb.String()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getBlackRuns returns runs of black pixels of a row. A run is a pair of the
+// first pixel's position and of the position following the last pixel.
+// Does not perform the fool checks.
+func (sbm *Sbm) getBlackRuns(y uint) (runs [][2]uint) {
+	width := sbm.pixelArray.metaData.width
+	for x := uint(0); x < width; x++ {
+		if sbm.getPixel(x, y) != bit.Zero {
+			continue
+		}
+		x0 := x
+		for (x < width) && (sbm.getPixel(x, y) == bit.Zero) {
+			x++
+		}
+		runs = append(runs, [2]uint{x0, x})
+	}
+
+	return runs
+}
+
+// gcodeNumber formats a coordinate in millimetres.
+func gcodeNumber(x float64) string {
+	s := strconv.FormatFloat(x, 'f', GcodePrecision, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+
+	return s
+}
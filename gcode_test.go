@@ -0,0 +1,60 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteGcode(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.Zero, bit.Zero,
+			bit.One, bit.One, bit.One, bit.One,
+			bit.One, bit.Zero, bit.Zero, bit.One,
+		},
+		4,
+		3,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Default options.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteGcode(buffer, GcodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "(SBM 4x3, 254 DPI)\nG21\nG90\nM4 S0\n"+
+		"G0 X0 Y0.2\nG1 X0.1 S1000 F1000\n"+
+		"G0 X0.2 Y0.2\nG1 X0.4 S1000\n"+
+		"G0 X0.1 Y0\nG1 X0.3 S1000\n"+
+		"M5\n")
+
+	// Test #2. Serpentine with an origin and constant power.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteGcode(buffer, GcodeOptions{
+		DPI: 127, FeedRate: 600, Power: 255, ConstantPower: true, Serpentine: true, OriginX: 10, OriginY: 5,
+	})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "(SBM 4x3, 127 DPI)\nG21\nG90\nM3 S0\n"+
+		"G0 X10 Y5.4\nG1 X10.2 S255 F600\n"+
+		"G0 X10.4 Y5.4\nG1 X10.8 S255\n"+
+		"G0 X10.6 Y5\nG1 X10.2 S255\n"+
+		"M5\n")
+}
+
+func Test_gcodeNumber(t *testing.T) {
+	tst := tester.New(t)
+	tst.MustBeEqual(gcodeNumber(0.30000000000000004), "0.3")
+	tst.MustBeEqual(gcodeNumber(-0.0001), "0")
+	tst.MustBeEqual(gcodeNumber(12), "12")
+	tst.MustBeEqual(gcodeNumber(1.2345), "1.234")
+}
@@ -0,0 +1,155 @@
+package sbm
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// GIF file parameters.
+const (
+	GifPaletteIndexBlack = 0
+	GifPaletteIndexWhite = 1
+	GifGrayThreshold     = 0x80
+)
+
+// Errors.
+const (
+	ErrGifNoFrames = "GIF has no frames"
+	ErrGifDelays   = "GIF delays count mismatch"
+)
+
+// gifPalette is the two-colour palette, where the colour index is the bit
+// value of SBM.
+var gifPalette = color.Palette{
+	GifPaletteIndexBlack: color.Black,
+	GifPaletteIndexWhite: color.White,
+}
+
+// WriteGIF writes an SBM object into the stream as a static GIF image.
+func (sbm *Sbm) WriteGIF(writer io.Writer) (err error) {
+	return WriteGIF(writer, []*Sbm{sbm}, GifOptions{})
+}
+
+// WriteGIF writes SBM objects into the stream as frames of a GIF animation.
+// Size of the animation is the size of the largest frame, all the frames are
+// placed at the top left corner.
+func WriteGIF(writer io.Writer, frames []*Sbm, opts GifOptions) (err error) {
+	if len(frames) == 0 {
+		return errors.New(ErrGifNoFrames)
+	}
+	if (opts.Delays != nil) && (len(opts.Delays) != len(frames)) {
+		return errors.New(ErrGifDelays)
+	}
+
+	g := &gif.GIF{
+		Image:           make([]*image.Paletted, 0, len(frames)),
+		Delay:           opts.Delays,
+		LoopCount:       opts.LoopCount,
+		BackgroundIndex: GifPaletteIndexWhite,
+	}
+	if g.Delay == nil {
+		g.Delay = make([]int, len(frames))
+	}
+
+	for _, frame := range frames {
+		width := frame.pixelArray.metaData.width
+		height := frame.pixelArray.metaData.height
+		if (width == 0) || (height == 0) {
+			return ErrorDimension
+		}
+
+		img := image.NewPaletted(image.Rect(0, 0, int(width), int(height)), gifPalette)
+		for y := uint(0); y < height; y++ {
+			row := img.Pix[int(y)*img.Stride:]
+			for x := uint(0); x < width; x++ {
+				if frame.getPixel(x, y) == bit.One {
+					row[x] = GifPaletteIndexWhite
+				}
+			}
+		}
+		g.Image = append(g.Image, img)
+
+		g.Config.Width = max(g.Config.Width, int(width))
+		g.Config.Height = max(g.Config.Height, int(height))
+	}
+	g.Config.ColorModel = gifPalette
+
+	return gif.EncodeAll(writer, g)
+}
+
+// NewFromGIF reads SBM objects from the stream containing a GIF image. Each
+// frame is composed onto the canvas of the animation respecting transparency
+// and disposal methods, the canvas is then converted into a bi-level image.
+// Light and transparent pixels become white, dark pixels become black.
+func NewFromGIF(reader io.Reader) (frames []*Sbm, opts GifOptions, err error) {
+	var g *gif.GIF
+	g, err = gif.DecodeAll(reader)
+	if err != nil {
+		return nil, opts, err
+	}
+	if len(g.Image) == 0 {
+		return nil, opts, errors.New(ErrGifNoFrames)
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		return nil, opts, ErrorDimension
+	}
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, image.White, image.Point{}, draw.Src)
+
+	frames = make([]*Sbm, 0, len(g.Image))
+	for i, img := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, draw.Over)
+
+		var frame *Sbm
+		frame, err = newFromImageThreshold(canvas, GifGrayThreshold)
+		if err != nil {
+			return nil, opts, err
+		}
+		frames = append(frames, frame)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, img.Bounds(), image.White, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	opts.Delays = g.Delay
+	opts.LoopCount = g.LoopCount
+
+	return frames, opts, nil
+}
+
+// newFromImageThreshold creates a new SBM from an image. Pixels having the
+// gray level not less than the threshold become white.
+func newFromImageThreshold(img image.Image, threshold uint8) (sbm *Sbm, err error) {
+	bounds := img.Bounds()
+	bits := make([]bit.Bit, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			bits = append(bits, bit.Bit(gray.Y >= threshold))
+		}
+	}
+
+	return NewFromBitsArray(bits, uint(bounds.Dx()), uint(bounds.Dy()))
+}
@@ -0,0 +1,113 @@
+package sbm
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteGIF(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var frame1 *Sbm
+	var frame2 *Sbm
+	var frames []*Sbm
+	var opts GifOptions
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	frame1, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+	frame2, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.Zero,
+			bit.One, bit.One, bit.Zero,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Animation round trip.
+	buffer = new(bytes.Buffer)
+	err = WriteGIF(buffer, []*Sbm{frame1, frame2}, GifOptions{Delays: []int{10, 20}, LoopCount: 3})
+	tst.MustBeNoError(err)
+	frames, opts, err = NewFromGIF(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(frames), 2)
+	tst.MustBeEqual(frames[0].GetArrayBits(), frame1.GetArrayBits())
+	tst.MustBeEqual(frames[1].GetArrayBits(), frame2.GetArrayBits())
+	tst.MustBeEqual(opts, GifOptions{Delays: []int{10, 20}, LoopCount: 3})
+
+	// Test #2. Static image.
+	buffer = new(bytes.Buffer)
+	err = frame1.WriteGIF(buffer)
+	tst.MustBeNoError(err)
+	frames, _, err = NewFromGIF(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(frames), 1)
+	tst.MustBeEqual(frames[0].GetArrayBits(), frame1.GetArrayBits())
+
+	// Test #3. Delays count mismatch.
+	err = WriteGIF(new(bytes.Buffer), []*Sbm{frame1, frame2}, GifOptions{Delays: []int{1}})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrGifDelays)
+
+	// Test #4. No frames.
+	err = WriteGIF(new(bytes.Buffer), nil, GifOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrGifNoFrames)
+}
+
+func Test_NewFromGIF(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var frames []*Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// A colour animation with a transparent partial frame.
+	palette := color.Palette{
+		color.RGBA{R: 0xFF, G: 0xFF, B: 0x00, A: 0xFF},
+		color.RGBA{R: 0x00, G: 0x00, B: 0x80, A: 0xFF},
+		color.Transparent,
+	}
+	img1 := image.NewPaletted(image.Rect(0, 0, 2, 2), palette)
+	img1.Pix = []uint8{0, 1, 1, 0}
+	img2 := image.NewPaletted(image.Rect(1, 0, 2, 2), palette)
+	img2.Pix = []uint8{2, 1}
+	buffer = new(bytes.Buffer)
+	err = gif.EncodeAll(buffer, &gif.GIF{
+		Image:    []*image.Paletted{img1, img2},
+		Delay:    []int{0, 0},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalNone},
+	})
+	tst.MustBeNoError(err)
+
+	// Test #1. Frames are composed and binarized.
+	frames, _, err = NewFromGIF(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(frames), 2)
+	tst.MustBeEqual(frames[0].GetArrayBits(), []bit.Bit{bit.One, bit.Zero, bit.Zero, bit.One})
+	tst.MustBeEqual(frames[1].GetArrayBits(), []bit.Bit{bit.One, bit.Zero, bit.Zero, bit.Zero})
+
+	// Test #2. Not a GIF.
+	_, _, err = NewFromGIF(bytes.NewReader([]byte("JUNK")))
+	tst.MustBeAnError(err)
+}
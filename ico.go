@@ -0,0 +1,270 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// ICO and CUR file parameters.
+const (
+	IcoTypeIcon         = 1
+	IcoTypeCursor       = 2
+	IcoHeaderSize       = 6
+	IcoEntrySize        = 16
+	IcoDimensionMax     = 256
+	IcoColoursCount     = 2
+	IcoPlanes           = 1
+	IcoBitsPerPixel     = 1
+	IcoPngSignature     = "\x89PNG"
+	IcoImagesCountMax   = 0xFFFF
+	IcoImageDataSizeMin = BmpInfoHeaderSize
+)
+
+// Errors.
+const (
+	ErrIcoNoImages      = "icon has no images"
+	ErrIcoTooLarge      = "image is too large for an icon"
+	ErrIcoMaskSize      = "icon mask size mismatch"
+	ErrIcoHeader        = "icon header error"
+	ErrIcoEntry         = "icon directory entry error"
+	ErrIcoHotspot       = "cursor hot spot is outside the image"
+	ErrIcoTooManyImages = "too many images for an icon"
+)
+
+// icoDirEntry is the ICONDIRENTRY structure.
+type icoDirEntry struct {
+	Width       uint8
+	Height      uint8
+	ColourCount uint8
+	Reserved    uint8
+	PlanesOrX   uint16
+	BitCountOrY uint16
+	Size        uint32
+	Offset      uint32
+}
+
+// WriteICO writes monochrome images into the stream as an ICO file.
+func WriteICO(writer io.Writer, images []IcoImage) (err error) {
+	return writeIco(writer, images, IcoTypeIcon)
+}
+
+// WriteCUR writes monochrome images into the stream as a CUR file.
+func WriteCUR(writer io.Writer, images []IcoImage) (err error) {
+	return writeIco(writer, images, IcoTypeCursor)
+}
+
+// writeIco writes monochrome images into the stream as an ICO or CUR file.
+func writeIco(writer io.Writer, images []IcoImage, iconType uint16) (err error) {
+	if len(images) == 0 {
+		return errors.New(ErrIcoNoImages)
+	}
+	if len(images) > IcoImagesCountMax {
+		return errors.New(ErrIcoTooManyImages)
+	}
+
+	// 1. Image data.
+	entries := make([]icoDirEntry, 0, len(images))
+	datas := make([][]byte, 0, len(images))
+	offset := uint32(IcoHeaderSize + IcoEntrySize*len(images))
+	for _, img := range images {
+		var data []byte
+		data, err = img.encode()
+		if err != nil {
+			return err
+		}
+
+		width := img.Image.pixelArray.metaData.width
+		height := img.Image.pixelArray.metaData.height
+		entry := icoDirEntry{
+			Width:       uint8(width % IcoDimensionMax),
+			Height:      uint8(height % IcoDimensionMax),
+			ColourCount: IcoColoursCount,
+			PlanesOrX:   IcoPlanes,
+			BitCountOrY: IcoBitsPerPixel,
+			Size:        uint32(len(data)),
+			Offset:      offset,
+		}
+		if iconType == IcoTypeCursor {
+			if (uint(img.HotspotX) >= width) || (uint(img.HotspotY) >= height) {
+				return errors.New(ErrIcoHotspot)
+			}
+			entry.PlanesOrX = img.HotspotX
+			entry.BitCountOrY = img.HotspotY
+		}
+
+		entries = append(entries, entry)
+		datas = append(datas, data)
+		offset += uint32(len(data))
+	}
+
+	// 2. Output.
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, [3]uint16{0, iconType, uint16(len(images))})
+	_ = binary.Write(buf, binary.LittleEndian, entries)
+	for _, data := range datas {
+		buf.Write(data)
+	}
+
+	_, err = writer.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// encode encodes the image as a device independent bitmap having the XOR
+// image followed by the AND mask.
+func (img IcoImage) encode() (data []byte, err error) {
+	if img.Image == nil {
+		return nil, ErrorDimension
+	}
+	width := img.Image.pixelArray.metaData.width
+	height := img.Image.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return nil, ErrorDimension
+	}
+	if (width > IcoDimensionMax) || (height > IcoDimensionMax) {
+		return nil, errors.New(ErrIcoTooLarge)
+	}
+	if (img.Mask != nil) &&
+		((img.Mask.pixelArray.metaData.width != width) || (img.Mask.pixelArray.metaData.height != height)) {
+		return nil, errors.New(ErrIcoMaskSize)
+	}
+
+	stride := bmpRowStride(width)
+	ih := bmpInfoHeader{
+		Size:        BmpInfoHeaderSize,
+		Width:       int32(width),
+		Height:      int32(2 * height),
+		Planes:      BmpPlanes,
+		BitCount:    BmpBitsPerPixel,
+		Compression: BmpCompressionRGB,
+		ImageSize:   uint32(2 * stride * height),
+		ColoursUsed: BmpPaletteEntriesCount,
+	}
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, ih)
+	buf.Write([]byte{0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0x00})
+
+	// XOR image and AND mask, both are bottom-up.
+	row := make([]byte, stride)
+	for _, part := range []*Sbm{img.Image, img.Mask} {
+		for i := uint(0); i < height; i++ {
+			clear(row)
+			if part != nil {
+				copy(row, part.getRowBytesMSB(height-1-i, false))
+			}
+			buf.Write(row)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewFromICO reads monochrome images from the stream containing an ICO or a
+// CUR file. Images which are not monochrome bitmaps are skipped. Type of the
+// file is returned together with the images.
+func NewFromICO(reader io.Reader) (images []IcoImage, iconType uint16, err error) {
+	var file []byte
+	file, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 1. Header.
+	if len(file) < IcoHeaderSize {
+		return nil, 0, errors.New(ErrIcoHeader)
+	}
+	iconType = binary.LittleEndian.Uint16(file[2:4])
+	count := int(binary.LittleEndian.Uint16(file[4:6]))
+	if (binary.LittleEndian.Uint16(file[0:2]) != 0) ||
+		((iconType != IcoTypeIcon) && (iconType != IcoTypeCursor)) ||
+		(len(file) < IcoHeaderSize+count*IcoEntrySize) {
+		return nil, 0, errors.New(ErrIcoHeader)
+	}
+
+	// 2. Images.
+	for i := 0; i < count; i++ {
+		var entry icoDirEntry
+		_ = binary.Read(bytes.NewReader(file[IcoHeaderSize+i*IcoEntrySize:]), binary.LittleEndian, &entry)
+		if uint64(entry.Offset)+uint64(entry.Size) > uint64(len(file)) {
+			return nil, 0, errors.New(ErrIcoEntry)
+		}
+		data := file[entry.Offset : entry.Offset+entry.Size]
+		if (len(data) < IcoImageDataSizeMin) || bytes.HasPrefix(data, []byte(IcoPngSignature)) {
+			continue
+		}
+
+		var img IcoImage
+		var ok bool
+		img, ok, err = decodeIcoImage(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			continue
+		}
+		if iconType == IcoTypeCursor {
+			img.HotspotX = entry.PlanesOrX
+			img.HotspotY = entry.BitCountOrY
+		}
+		images = append(images, img)
+	}
+
+	return images, iconType, nil
+}
+
+// decodeIcoImage decodes a device independent bitmap having the XOR image
+// followed by the AND mask. Bitmaps which are not monochrome are ignored.
+func decodeIcoImage(data []byte) (img IcoImage, ok bool, err error) {
+	var ih bmpInfoHeader
+	_ = binary.Read(bytes.NewReader(data), binary.LittleEndian, &ih)
+	if (ih.BitCount != BmpBitsPerPixel) || (ih.Compression != BmpCompressionRGB) {
+		return img, false, nil
+	}
+	if (ih.Size < BmpInfoHeaderSize) || (ih.Width <= 0) || (ih.Width > IcoDimensionMax) ||
+		(ih.Height <= 0) || (ih.Height > 2*IcoDimensionMax) || (ih.Height%2 != 0) {
+		return img, false, errors.New(ErrIcoEntry)
+	}
+	width := uint(ih.Width)
+	height := uint(ih.Height) / 2
+
+	paletteEntries := uint(ih.ColoursUsed)
+	if paletteEntries == 0 {
+		paletteEntries = BmpPaletteEntriesCount
+	}
+	stride := bmpRowStride(width)
+	paletteOffset := uint(ih.Size)
+	xorOffset := paletteOffset + paletteEntries*BmpPaletteEntrySize
+	andOffset := xorOffset + stride*height
+	if (paletteEntries < BmpPaletteEntriesCount) || (paletteEntries > BmpPaletteEntriesMax) ||
+		(andOffset+stride*height > uint(len(data))) {
+		return img, false, errors.New(ErrIcoEntry)
+	}
+	inverted := bmpLuminance(data[paletteOffset:]) > bmpLuminance(data[paletteOffset+BmpPaletteEntrySize:])
+
+	xorBits := make([]bit.Bit, 0, width*height)
+	andBits := make([]bit.Bit, 0, width*height)
+	for y := uint(0); y < height; y++ {
+		i := height - 1 - y
+		xorBits = appendRowBitsMSB(xorBits, data[xorOffset+i*stride:], width, inverted)
+		andBits = appendRowBitsMSB(andBits, data[andOffset+i*stride:], width, false)
+	}
+
+	img.Image, err = NewFromBitsArray(xorBits, width, height)
+	if err != nil {
+		return img, false, err
+	}
+	img.Mask, err = NewFromBitsArray(andBits, width, height)
+	if err != nil {
+		return img, false, err
+	}
+
+	return img, true, nil
+}
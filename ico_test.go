@@ -0,0 +1,134 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteICO(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var iconType uint16
+	var image *Sbm
+	var images []IcoImage
+	var mask *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	image, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+	mask, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.Zero, bit.One,
+			bit.One, bit.Zero, bit.Zero,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Icon with two images.
+	buffer = new(bytes.Buffer)
+	err = WriteICO(buffer, []IcoImage{{Image: image, Mask: mask}, {Image: image}})
+	tst.MustBeNoError(err)
+	file := buffer.Bytes()
+	tst.MustBeEqual(file[:IcoHeaderSize], []byte{0, 0, IcoTypeIcon, 0, 2, 0})
+	tst.MustBeEqual(file[IcoHeaderSize:IcoHeaderSize+8], []byte{3, 2, 2, 0, 1, 0, 1, 0})
+
+	images, iconType, err = NewFromICO(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(iconType, uint16(IcoTypeIcon))
+	tst.MustBeEqual(len(images), 2)
+	tst.MustBeEqual(images[0].Image.GetArrayBits(), image.GetArrayBits())
+	tst.MustBeEqual(images[0].Mask.GetArrayBits(), mask.GetArrayBits())
+	tst.MustBeEqual(images[1].Image.GetArrayBits(), image.GetArrayBits())
+	tst.MustBeEqual(images[1].Mask.GetArrayBits(), make([]bit.Bit, 6))
+
+	// Test #2. Cursor with a hot spot.
+	buffer = new(bytes.Buffer)
+	err = WriteCUR(buffer, []IcoImage{{Image: image, Mask: mask, HotspotX: 2, HotspotY: 1}})
+	tst.MustBeNoError(err)
+	file = buffer.Bytes()
+	tst.MustBeEqual(binary.LittleEndian.Uint16(file[2:4]), uint16(IcoTypeCursor))
+
+	images, iconType, err = NewFromICO(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(iconType, uint16(IcoTypeCursor))
+	tst.MustBeEqual(len(images), 1)
+	tst.MustBeEqual(images[0].HotspotX, uint16(2))
+	tst.MustBeEqual(images[0].HotspotY, uint16(1))
+	tst.MustBeEqual(images[0].Mask.GetArrayBits(), mask.GetArrayBits())
+
+	// Test #3. Hot spot outside the image.
+	err = WriteCUR(new(bytes.Buffer), []IcoImage{{Image: image, HotspotX: 3}})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrIcoHotspot)
+
+	// Test #4. Mask size mismatch.
+	err = WriteICO(new(bytes.Buffer), []IcoImage{{Image: image, Mask: &Sbm{}}})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrIcoMaskSize)
+
+	// Test #5. No images.
+	err = WriteICO(new(bytes.Buffer), nil)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrIcoNoImages)
+}
+
+func Test_NewFromICO(t *testing.T) {
+
+	var err error
+	var images []IcoImage
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// An icon having a PNG image and a monochrome image with an inverted
+	// palette.
+	png := []byte(IcoPngSignature + "\r\n\x1a\n" + "0123456789012345678901234567890123456789")
+	dib := new(bytes.Buffer)
+	_ = binary.Write(dib, binary.LittleEndian, bmpInfoHeader{
+		Size: BmpInfoHeaderSize, Width: 3, Height: 2, Planes: 1, BitCount: 1,
+	})
+	dib.Write([]byte{0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00})
+	dib.Write([]byte{0x40, 0, 0, 0, 0x20, 0, 0, 0})
+	file := []byte{0, 0, 1, 0, 2, 0}
+	file = append(file, 0, 0, 0, 0, 1, 0, 32, 0)
+	file = binary.LittleEndian.AppendUint32(file, uint32(len(png)))
+	file = binary.LittleEndian.AppendUint32(file, 38)
+	file = append(file, 3, 1, 2, 0, 1, 0, 1, 0)
+	file = binary.LittleEndian.AppendUint32(file, uint32(dib.Len()))
+	file = binary.LittleEndian.AppendUint32(file, uint32(38+len(png)))
+	file = append(file, png...)
+	file = append(file, dib.Bytes()...)
+
+	// Test #1. Only the monochrome image is read.
+	images, _, err = NewFromICO(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(images), 1)
+	tst.MustBeEqual(images[0].Image.GetArrayBits(), []bit.Bit{bit.One, bit.Zero, bit.One})
+	tst.MustBeEqual(images[0].Mask.GetArrayBits(), []bit.Bit{bit.Zero, bit.Zero, bit.One})
+
+	// Test #2. Entry is out of the file.
+	_, _, err = NewFromICO(bytes.NewReader(file[:len(file)-1]))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrIcoEntry)
+
+	// Test #3. Bad header.
+	_, _, err = NewFromICO(bytes.NewReader([]byte{0, 0, 3, 0, 0, 0}))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrIcoHeader)
+}
@@ -0,0 +1,77 @@
+package sbm
+
+import (
+	"io"
+)
+
+// Header is the meta-data of an SBM object stored in its headers.
+type Header struct {
+	Version byte
+	Width   HeaderValue
+	Height  HeaderValue
+	Area    HeaderValue
+}
+
+// HeaderValue is a size stored in the top and in the bottom headers together
+// with its random splits. Sum of every pair of splits is equal to the value.
+type HeaderValue struct {
+	Value       uint
+	TopLeft     uint
+	TopRight    uint
+	BottomLeft  uint
+	BottomRight uint
+}
+
+// Header returns the meta-data of the object.
+func (sbm *Sbm) Header() Header {
+	return Header{
+		Version: sbm.format.version,
+		Width:   newHeaderValue(sbm.pixelArray.metaData.width, sbm.pixelArray.metaData.header.width),
+		Height:  newHeaderValue(sbm.pixelArray.metaData.height, sbm.pixelArray.metaData.header.height),
+		Area:    newHeaderValue(sbm.pixelArray.metaData.area, sbm.pixelArray.metaData.header.area),
+	}
+}
+
+// newHeaderValue creates a header value from its internal representation.
+func newHeaderValue(value uint, data SbmPixelArrayMetaDataHeaderData) HeaderValue {
+	return HeaderValue{
+		Value:       value,
+		TopLeft:     data.topLeft,
+		TopRight:    data.topRight,
+		BottomLeft:  data.bottomLeft,
+		BottomRight: data.bottomRight,
+	}
+}
+
+// ReadHeader reads the meta-data of an SBM object from the stream without
+// decoding the pixel array. The pixel array is skipped, using the io.Seeker
+// interface when the stream has it. Both top and bottom headers are verified.
+func ReadHeader(reader io.Reader) (header Header, err error) {
+	sbm := new(Sbm)
+	lineReader := newLineReader(reader)
+
+	// Read the top headers.
+	err = sbm.readTopHeaders(lineReader, DecodeOptions{})
+	if err != nil {
+		return header, err
+	}
+
+	// Skip the array.
+	arraySize := int64(sbm.arraySize())
+	err = lineReader.GetInternalReader().(*countingReader).skip(arraySize)
+	if err != nil {
+		return header, newParseError(ParseSectionArray, "", LineNumberArray, readerOffset(lineReader), nil, err)
+	}
+
+	// Read the separator and the bottom headers.
+	err = readSeparator(lineReader)
+	if err != nil {
+		return header, err
+	}
+	err = sbm.readBottomHeaders(lineReader, DecodeOptions{})
+	if err != nil {
+		return header, err
+	}
+
+	return sbm.Header(), nil
+}
@@ -0,0 +1,56 @@
+package sbm
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ReadHeader(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var header Header
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromText(`
+		#..#.
+		.##.#
+		##..#
+	`, TextOptions{})
+	tst.MustBeNoError(err)
+	buffer = new(bytes.Buffer)
+	err = sbm.Write(buffer)
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+
+	// Test #1. Header of an object.
+	header = sbm.Header()
+	tst.MustBeEqual(header.Version, byte(SbmFormatVersion1))
+	tst.MustBeEqual(header.Width.Value, uint(5))
+	tst.MustBeEqual(header.Height.Value, uint(3))
+	tst.MustBeEqual(header.Area.Value, uint(15))
+	for _, v := range []HeaderValue{header.Width, header.Height, header.Area} {
+		tst.MustBeEqual(v.TopLeft+v.TopRight, v.Value)
+		tst.MustBeEqual(v.BottomLeft+v.BottomRight, v.Value)
+	}
+
+	// Test #2. Seekable stream.
+	header, err = ReadHeader(bytes.NewReader(data))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(header, sbm.Header())
+
+	// Test #3. Stream without seeking.
+	header, err = ReadHeader(io.MultiReader(bytes.NewReader(data)))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(header, sbm.Header())
+
+	// Test #4. Truncated stream.
+	_, err = ReadHeader(bytes.NewReader(data[:len(data)-1]))
+	tst.MustBeAnError(err)
+}
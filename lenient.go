@@ -0,0 +1,292 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	rdr "github.com/vault-thirteen/auxie/reader"
+)
+
+// Texts of the warnings of the lenient decoder.
+const (
+	WarnLineEndingLF       = "line ends with LF instead of CR LF"
+	WarnLineEndingMissing  = "line ending is missing"
+	WarnTrailingWhitespace = "trailing whitespace is removed"
+	WarnfArrayTruncated    = "pixel array is truncated, %d of %d bytes are padded with white pixels"
+	WarnSeparatorMissing   = "separator is missing"
+	WarnfArrayShortened    = "pixel array is probably shortened by %d bytes, its line endings may be converted"
+	WarnHeaderMissing      = "header is missing, the top header is used"
+	WarnfHeaderDamaged     = "header is damaged (%v), the top header is used"
+	WarnHeaderMismatch     = "header does not match the top header, the top header is used"
+)
+
+// Characters removed from the end of header lines by the lenient decoder.
+const lenientTrailingSpace = " \t\r"
+
+// ArrayPaddingByte is a byte of white pixels used to pad a truncated array.
+const ArrayPaddingByte = 0xFF
+
+// lenientReader reads an SBM file repairing its defects.
+type lenientReader struct {
+	lineReader *rdr.Reader
+	opts       DecodeOptions
+	warnings   []DecodeWarning
+
+	// pending is a line which has been read ahead.
+	pending *headerLine
+
+	// isFinished is set when the end of the stream is reached.
+	isFinished bool
+
+	// arrayOffset and arrayTail are the offset and the last bytes of the
+	// pixel array. They show whether the array has swallowed a part of the
+	// separator.
+	arrayOffset int64
+	arrayTail   []byte
+}
+
+// NewFromStreamLenient reads an SBM object from the stream repairing the
+// defects of damaged files. Lines may end with LF instead of CR LF and may
+// have trailing whitespace. Missing or damaged bottom headers are replaced
+// with the top headers, a truncated pixel array is padded with white pixels.
+// The top headers must be readable. A pixel array shortened by a conversion of
+// line endings can not be repaired, it is reported with a warning. The list of
+// repaired defects is returned together with the object.
+func NewFromStreamLenient(reader io.Reader, opts DecodeOptions) (sbm *Sbm, warnings []DecodeWarning, err error) {
+	sbm = new(Sbm)
+	lr := &lenientReader{
+		lineReader: newLineReader(reader),
+		opts:       opts,
+	}
+
+	// Read the top headers.
+	err = sbm.parseTopHeaders(lr.readLine, opts)
+	if err != nil {
+		return nil, lr.warnings, err
+	}
+
+	// Read the binary array of bits and the separator.
+	err = sbm.readArrayDataLenient(lr)
+	if err != nil {
+		return nil, lr.warnings, err
+	}
+	if !lr.isFinished {
+		err = lr.readSeparator()
+		if err != nil {
+			return nil, lr.warnings, err
+		}
+	}
+
+	// Read the bottom headers.
+	err = sbm.readBottomHeadersLenient(lr)
+	if err != nil {
+		return nil, lr.warnings, err
+	}
+
+	return sbm, lr.warnings, nil
+}
+
+// warn adds a warning about a line.
+func (lr *lenientReader) warn(hl headerLine, message string) {
+	lr.warnings = append(lr.warnings, DecodeWarning{
+		Section: hl.section,
+		Header:  hl.name,
+		Line:    hl.number,
+		Offset:  hl.offset,
+		Message: message,
+	})
+}
+
+// readLine reads a line ending with LF or with CR LF, or the last line of the
+// stream. The line is returned trimmed and ending with CR LF, so that it may
+// be parsed as a strict header.
+func (lr *lenientReader) readLine(section ParseSection, name string, number int) (hl headerLine, err error) {
+	if lr.pending != nil {
+		hl = *lr.pending
+		hl.section, hl.name, hl.number = section, name, number
+		lr.pending = nil
+		return hl, nil
+	}
+
+	hl = headerLine{
+		section: section,
+		name:    name,
+		number:  number,
+		offset:  readerOffset(lr.lineReader),
+	}
+
+	// Read the line.
+	var b byte
+	var hasEnding bool
+	for !hasEnding {
+		b, err = lr.lineReader.ReadByte()
+		if errors.Is(err, io.EOF) {
+			lr.isFinished = true
+			if len(hl.raw) == 0 {
+				return hl, hl.error(err)
+			}
+			lr.warn(hl, WarnLineEndingMissing)
+			break
+		}
+		if err != nil {
+			return hl, hl.error(err)
+		}
+
+		hl.raw = append(hl.raw, b)
+		hasEnding = b == LF
+		if !hasEnding && (lr.opts.MaxHeaderLineLength != 0) && (uint(len(hl.raw)) >= lr.opts.MaxHeaderLineLength) {
+			return hl, hl.error(errorLineLength(lr.opts.MaxHeaderLineLength))
+		}
+	}
+
+	// Repair the line.
+	line := hl.raw
+	if hasEnding {
+		line = line[:len(line)-1]
+		if !bytes.HasSuffix(line, []byte{CR}) {
+			lr.warn(hl, WarnLineEndingLF)
+		} else {
+			line = line[:len(line)-1]
+		}
+	}
+	trimmedLine := bytes.TrimRight(line, lenientTrailingSpace)
+	if len(trimmedLine) != len(line) {
+		lr.warn(hl, WarnTrailingWhitespace)
+	}
+	hl.raw = append(trimmedLine[:len(trimmedLine):len(trimmedLine)], CR, LF)
+
+	return hl, nil
+}
+
+// readArrayDataLenient reads the pixel array padding it when it is truncated.
+func (sbm *Sbm) readArrayDataLenient(lr *lenientReader) (err error) {
+	offset := readerOffset(lr.lineReader)
+
+	readBytesSize := sbm.arraySize()
+	if readBytesSize > math.MaxInt {
+		return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, ErrorOverflow)
+	}
+
+	bytesArray := make([]byte, 0, min(readBytesSize, ArrayChunkSize))
+	for uint(len(bytesArray)) < readBytesSize {
+		var chunk []byte
+		chunk, err = lr.lineReader.ReadBytes(int(min(readBytesSize-uint(len(bytesArray)), ArrayChunkSize)))
+		bytesArray = append(bytesArray, chunk...)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			lr.isFinished = true
+			lr.warn(headerLine{section: ParseSectionArray, number: LineNumberArray, offset: offset},
+				fmt.Sprintf(WarnfArrayTruncated, readBytesSize-uint(len(bytesArray)), readBytesSize))
+			bytesArray = append(bytesArray, bytes.Repeat([]byte{ArrayPaddingByte}, int(readBytesSize)-len(bytesArray))...)
+			break
+		}
+		if err != nil {
+			return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, err)
+		}
+	}
+
+	lr.arrayOffset = offset
+	lr.arrayTail = bytesArray[max(len(bytesArray), len(NL))-len(NL):]
+	sbm.saveArray(bytesArray)
+
+	return nil
+}
+
+// readSeparator reads the separator. When the separator is missing, the line
+// is kept for the bottom headers. When the pixel array ends with the missing
+// part of the separator, the array has been shortened, e.g. by a conversion
+// of CR LF into LF inside it, and its pixels are shifted. Such an array can
+// not be repaired, so that a warning is added.
+func (lr *lenientReader) readSeparator() (err error) {
+	var hl headerLine
+	hl, err = lr.readLine(ParseSectionSeparator, "", LineNumberArray)
+	if errors.Is(err, io.EOF) {
+		lr.warn(hl, WarnSeparatorMissing)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	separatorSize := readerOffset(lr.lineReader) - hl.offset
+	isSeparator := bytes.Equal(hl.raw, []byte(NL))
+	if !isSeparator {
+		lr.warn(hl, WarnSeparatorMissing)
+		lr.pending = &hl
+	}
+
+	// Count the bytes of the separator swallowed by the array.
+	var shortening int
+	switch {
+	case isSeparator && (separatorSize == 1) && bytes.HasSuffix(lr.arrayTail, []byte{CR}):
+		shortening = 1
+	case !isSeparator && bytes.HasSuffix(lr.arrayTail, []byte(NL)):
+		shortening = 2
+	case !isSeparator && bytes.HasSuffix(lr.arrayTail, []byte{LF}):
+		shortening = 1
+	}
+	if shortening > 0 {
+		lr.warn(headerLine{section: ParseSectionArray, number: LineNumberArray, offset: lr.arrayOffset},
+			fmt.Sprintf(WarnfArrayShortened, shortening))
+	}
+
+	return nil
+}
+
+// readBottomHeadersLenient reads the bottom headers replacing the missing and
+// damaged ones with the top headers.
+func (sbm *Sbm) readBottomHeadersLenient(lr *lenientReader) (err error) {
+	headers := []struct {
+		name   string
+		number int
+		value  uint
+		data   *SbmPixelArrayMetaDataHeaderData
+	}{
+		{HeaderPrefix_Width, LineNumberBottomWidth, sbm.pixelArray.metaData.width, &sbm.pixelArray.metaData.header.width},
+		{HeaderPrefix_Height, LineNumberBottomHeight, sbm.pixelArray.metaData.height, &sbm.pixelArray.metaData.header.height},
+		{HeaderPrefix_Area, LineNumberBottomArea, sbm.pixelArray.metaData.area, &sbm.pixelArray.metaData.header.area},
+	}
+
+	for _, h := range headers {
+		// Replace the header with the top one, it is undone when the header
+		// is correct.
+		h.data.bottomLeft, h.data.bottomRight = h.data.topLeft, h.data.topRight
+
+		hl := headerLine{
+			section: ParseSectionBottomHeader,
+			name:    h.name,
+			number:  h.number,
+			offset:  readerOffset(lr.lineReader),
+		}
+		if lr.isFinished && (lr.pending == nil) {
+			lr.warn(hl, WarnHeaderMissing)
+			continue
+		}
+
+		hl, err = lr.readLine(ParseSectionBottomHeader, h.name, h.number)
+		if errors.Is(err, io.EOF) {
+			lr.warn(hl, WarnHeaderMissing)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		var headerSize HeaderDataSize
+		headerSize, err = parseHeaderSize(hl.raw, h.name)
+		if err != nil {
+			lr.warn(hl, fmt.Sprintf(WarnfHeaderDamaged, err))
+			continue
+		}
+		if headerSize.sizeFixed != h.value {
+			lr.warn(hl, WarnHeaderMismatch)
+			continue
+		}
+
+		h.data.bottomLeft, h.data.bottomRight = headerSize.sizeRandomLeft, headerSize.sizeRandomRight
+	}
+
+	return nil
+}
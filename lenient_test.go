@@ -0,0 +1,127 @@
+package sbm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NewFromStreamLenient(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+	var warnings []DecodeWarning
+
+	tst = tester.New(t)
+
+	top := "SBM (SIMPLE BIT MAP)" + NL + "VERSION 1" + NL +
+		"WIDTH 3 (2 + 1)" + NL + "HEIGHT 4 (3 + 1)" + NL + "AREA 12 (11 + 1)" + NL
+	bottom := "WIDTH 3 (0 + 3)" + NL + "HEIGHT 4 (0 + 4)" + NL + "AREA 12 (10 + 2)" + NL
+	data := top + "\xF0\x0F" + NL + bottom
+
+	// Test #1. Correct file.
+	sbm, warnings, err = NewFromStreamLenient(strings.NewReader(data), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(warnings), 0)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.area.bottomLeft, uint(10))
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.area.bottomRight, uint(2))
+
+	// Test #2. LF line endings and trailing whitespace.
+	sbm, warnings, err = NewFromStreamLenient(strings.NewReader(
+		strings.ReplaceAll(top, NL, "\n")+"\xF0\x0F\n"+"WIDTH 3 (0 + 3) \t\r\n"+
+			"HEIGHT 4 (0 + 4)\n"+"AREA 12 (10 + 2)"), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayBits(), []bit.Bit{
+		bit.Zero, bit.Zero, bit.Zero,
+		bit.Zero, bit.One, bit.One,
+		bit.One, bit.One, bit.One,
+		bit.One, bit.One, bit.One,
+	})
+	messages := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		messages = append(messages, w.Message)
+	}
+	tst.MustBeEqual(messages, []string{
+		WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF,
+		WarnLineEndingLF,
+		WarnTrailingWhitespace,
+		WarnLineEndingLF,
+		WarnLineEndingMissing,
+	})
+	tst.MustBeEqual(warnings[0].String(), "top header SBM, line 1, offset 0: "+WarnLineEndingLF)
+	tst.MustBeEqual(warnings[5].Section, ParseSectionSeparator)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.width.bottomRight, uint(3))
+
+	// Test #3. Truncated array.
+	sbm, warnings, err = NewFromStreamLenient(strings.NewReader(top+"\x00"), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayBytes(), []byte{0x00, 0x0F})
+	tst.MustBeEqual(len(warnings), 4)
+	tst.MustBeEqual(warnings[0].String(), fmt.Sprintf("pixel array, line 6, offset %d: ", len(top))+
+		fmt.Sprintf(WarnfArrayTruncated, 1, 2))
+	tst.MustBeEqual(warnings[3].Header, HeaderPrefix_Area)
+	tst.MustBeEqual(warnings[3].Message, WarnHeaderMissing)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.area.bottomLeft, uint(11))
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.area.bottomRight, uint(1))
+
+	// Test #4. Damaged and mismatched bottom headers.
+	sbm, warnings, err = NewFromStreamLenient(strings.NewReader(top+"\xF0\x0F"+NL+
+		"WIDTH 3 (0 + 2)"+NL+"HEIGHT 5 (0 + 5)"+NL+"AREA 12 (10 + 2)"+NL), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(warnings), 2)
+	tst.MustBeEqual(warnings[0].Message, fmt.Sprintf(WarnfHeaderDamaged, ErrIntegrity))
+	tst.MustBeEqual(warnings[1].Message, WarnHeaderMismatch)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.width.bottomLeft, uint(2))
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.height.bottomLeft, uint(3))
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.area.bottomLeft, uint(10))
+
+	// Test #5. Missing separator.
+	sbm, warnings, err = NewFromStreamLenient(strings.NewReader(top+"\xF0\x0F"+bottom), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(warnings), 1)
+	tst.MustBeEqual(warnings[0].Message, WarnSeparatorMissing)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header.width.bottomRight, uint(3))
+
+	// Test #6. Damaged top header is not repaired.
+	_, _, err = NewFromStreamLenient(strings.NewReader(top[:40]), DecodeOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorHeaderSyntax), true)
+	_, _, err = NewFromStreamLenient(strings.NewReader(top[:22]), DecodeOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.EOF), true)
+
+	// Test #7. Recovered object may be written as a correct file.
+	sbm, _, err = NewFromStreamLenient(strings.NewReader(top+"\xF0"), DecodeOptions{})
+	tst.MustBeNoError(err)
+	var sb strings.Builder
+	err = sbm.Write(&sb)
+	tst.MustBeNoError(err)
+	_, err = NewFromStream(strings.NewReader(sb.String()))
+	tst.MustBeNoError(err)
+
+	// Test #8. CR LF inside the pixel array converted into LF.
+	_, warnings, err = NewFromStreamLenient(strings.NewReader(top+"\x0A"+NL+bottom), DecodeOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(len(warnings), 2)
+	tst.MustBeEqual(warnings[0].Message, WarnLineEndingLF)
+	tst.MustBeEqual(warnings[1].String(), fmt.Sprintf("pixel array, line 6, offset %d: ", len(top))+
+		fmt.Sprintf(WarnfArrayShortened, 1))
+	_, warnings, err = NewFromStreamLenient(strings.NewReader(
+		strings.ReplaceAll(top+"\x0D\x0A"+NL+bottom, NL, "\n")), DecodeOptions{})
+	tst.MustBeNoError(err)
+	messages = messages[:0]
+	for _, w := range warnings {
+		messages = append(messages, w.Message)
+	}
+	tst.MustBeEqual(messages, []string{
+		WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF, WarnLineEndingLF,
+		WarnLineEndingLF, WarnSeparatorMissing, fmt.Sprintf(WarnfArrayShortened, 1),
+		WarnLineEndingLF, WarnLineEndingLF,
+	})
+}
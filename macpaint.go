@@ -0,0 +1,193 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// MacPaint file parameters.
+const (
+	MacPaintHeaderSize       = 512
+	MacPaintRowSize          = MacPaintWidth / bit.BitsPerByte
+	MacPaintVersionDefault   = 0
+	MacPaintVersionPatterns  = 2
+	MacPaintFileType         = "PNTG"
+	MacPaintFileCreator      = "MPNT"
+	MacBinaryHeaderSize      = 128
+	MacBinaryBlockSize       = 128
+	MacBinaryFileNameSizeMax = 63
+	MacBinaryVersion2        = 129
+)
+
+// Offsets of the MacBinary header fields.
+const (
+	macBinaryOffsetFileNameLength = 1
+	macBinaryOffsetFileName       = 2
+	macBinaryOffsetFileType       = 65
+	macBinaryOffsetFileCreator    = 69
+	macBinaryOffsetZero1          = 74
+	macBinaryOffsetZero2          = 82
+	macBinaryOffsetDataForkLength = 83
+	macBinaryOffsetVersion        = 122
+	macBinaryOffsetMinVersion     = 123
+	macBinaryOffsetCrc            = 124
+)
+
+// Errors.
+const (
+	ErrMacPaintTooLarge = "image does not fit the MacPaint canvas"
+	ErrMacPaintFileName = "MacBinary file name error"
+	ErrMacBinaryData    = "MacBinary data fork is truncated"
+)
+
+// WriteMacPaint writes an SBM object into the stream as a MacPaint file. The
+// image is placed onto the white canvas of the fixed size.
+func (sbm *Sbm) WriteMacPaint(writer io.Writer, opts MacPaintOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if (opts.X > MacPaintWidth) || (width > MacPaintWidth-opts.X) ||
+		(opts.Y > MacPaintHeight) || (height > MacPaintHeight-opts.Y) {
+		return errors.New(ErrMacPaintTooLarge)
+	}
+
+	// 1. Header.
+	file := make([]byte, MacPaintHeaderSize)
+	version := uint32(MacPaintVersionDefault)
+	for i, pattern := range opts.Patterns {
+		copy(file[4+i*MacPaintPatternSize:], pattern[:])
+		if pattern != [MacPaintPatternSize]byte{} {
+			version = MacPaintVersionPatterns
+		}
+	}
+	binary.BigEndian.PutUint32(file, version)
+
+	// 2. Pixels. The one bit is black in MacPaint.
+	row := make([]byte, MacPaintRowSize)
+	for y := uint(0); y < MacPaintHeight; y++ {
+		clear(row)
+		if (y >= opts.Y) && (y < opts.Y+height) {
+			for x := uint(0); x < width; x++ {
+				if sbm.getPixel(x, y-opts.Y) == bit.Zero {
+					cx := opts.X + x
+					row[cx/bit.BitsPerByte] |= 0x80 >> (cx % bit.BitsPerByte)
+				}
+			}
+		}
+		file = append(file, packBitsEncode(row)...)
+	}
+
+	// 3. Container.
+	if opts.MacBinary {
+		file, err = wrapMacBinary(file, opts.FileName)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = writer.Write(file)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewFromMacPaint reads an SBM object from the stream containing a MacPaint
+// file. A file wrapped into a MacBinary container is detected automatically.
+// The object always has the size of the MacPaint canvas.
+func NewFromMacPaint(reader io.Reader) (sbm *Sbm, err error) {
+	var file []byte
+	file, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if isMacBinary(file) {
+		dataForkLength := uint64(binary.BigEndian.Uint32(file[macBinaryOffsetDataForkLength:]))
+		if MacBinaryHeaderSize+dataForkLength > uint64(len(file)) {
+			return nil, errors.New(ErrMacBinaryData)
+		}
+		file = file[MacBinaryHeaderSize : MacBinaryHeaderSize+dataForkLength]
+	}
+
+	if len(file) < MacPaintHeaderSize {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	// Rows are compressed separately, but some applications let runs cross
+	// the rows, so the whole bitmap is decompressed at once.
+	var data []byte
+	data, _, err = packBitsDecode(file[MacPaintHeaderSize:], MacPaintRowSize*MacPaintHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := make([]bit.Bit, 0, MacPaintWidth*MacPaintHeight)
+	for y := 0; y < MacPaintHeight; y++ {
+		bits = appendRowBitsMSB(bits, data[y*MacPaintRowSize:], MacPaintWidth, true)
+	}
+
+	return NewFromBitsArray(bits, MacPaintWidth, MacPaintHeight)
+}
+
+// isMacBinary tells whether the data starts with a MacBinary header of a
+// MacPaint file.
+func isMacBinary(data []byte) bool {
+	if len(data) < MacBinaryHeaderSize {
+		return false
+	}
+
+	return (data[0] == 0) &&
+		(data[macBinaryOffsetFileNameLength] >= 1) &&
+		(data[macBinaryOffsetFileNameLength] <= MacBinaryFileNameSizeMax) &&
+		(data[macBinaryOffsetZero1] == 0) &&
+		(data[macBinaryOffsetZero2] == 0) &&
+		bytes.Equal(data[macBinaryOffsetFileType:macBinaryOffsetFileType+4], []byte(MacPaintFileType))
+}
+
+// wrapMacBinary wraps the data fork of a MacPaint file into a MacBinary II
+// container.
+func wrapMacBinary(dataFork []byte, fileName string) (file []byte, err error) {
+	if (len(fileName) == 0) || (len(fileName) > MacBinaryFileNameSizeMax) {
+		return nil, errors.New(ErrMacPaintFileName)
+	}
+
+	header := make([]byte, MacBinaryHeaderSize)
+	header[macBinaryOffsetFileNameLength] = byte(len(fileName))
+	copy(header[macBinaryOffsetFileName:], fileName)
+	copy(header[macBinaryOffsetFileType:], MacPaintFileType)
+	copy(header[macBinaryOffsetFileCreator:], MacPaintFileCreator)
+	binary.BigEndian.PutUint32(header[macBinaryOffsetDataForkLength:], uint32(len(dataFork)))
+	header[macBinaryOffsetVersion] = MacBinaryVersion2
+	header[macBinaryOffsetMinVersion] = MacBinaryVersion2
+	binary.BigEndian.PutUint16(header[macBinaryOffsetCrc:], crc16Xmodem(header[:macBinaryOffsetCrc]))
+
+	file = append(header, dataFork...)
+	if padding := len(dataFork) % MacBinaryBlockSize; padding != 0 {
+		file = append(file, make([]byte, MacBinaryBlockSize-padding)...)
+	}
+
+	return file, nil
+}
+
+// crc16Xmodem calculates the CRC-16/XMODEM checksum used by MacBinary II.
+func crc16Xmodem(data []byte) (crc uint16) {
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < bit.BitsPerByte; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
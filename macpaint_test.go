@@ -0,0 +1,115 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteMacPaint(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var result *Sbm
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Small image on the canvas.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteMacPaint(buffer, MacPaintOptions{X: 10, Y: 700})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(binary.BigEndian.Uint32(buffer.Bytes()), uint32(MacPaintVersionDefault))
+
+	result, err = NewFromMacPaint(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result.GetArrayWidth(), uint(MacPaintWidth))
+	tst.MustBeEqual(result.GetArrayHeight(), uint(MacPaintHeight))
+	for y := uint(0); y < MacPaintHeight; y++ {
+		for x := uint(0); x < MacPaintWidth; x++ {
+			expected := bit.One
+			if (x >= 10) && (x < 13) && (y >= 700) && (y < 702) {
+				expected = sbm.getPixel(x-10, y-700)
+			}
+			tst.MustBeEqual(result.getPixel(x, y), expected)
+		}
+	}
+
+	// Test #2. Full canvas with patterns in a MacBinary container.
+	var opts MacPaintOptions
+	opts.Patterns[1] = [MacPaintPatternSize]byte{0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55}
+	opts.MacBinary = true
+	opts.FileName = "Picture"
+	buffer = new(bytes.Buffer)
+	err = result.WriteMacPaint(buffer, opts)
+	tst.MustBeNoError(err)
+	file := buffer.Bytes()
+	tst.MustBeEqual(len(file)%MacBinaryBlockSize, 0)
+	tst.MustBeEqual(string(file[65:69]), MacPaintFileType)
+	tst.MustBeEqual(binary.BigEndian.Uint16(file[124:126]), crc16Xmodem(file[:124]))
+	tst.MustBeEqual(binary.BigEndian.Uint32(file[MacBinaryHeaderSize:]), uint32(MacPaintVersionPatterns))
+
+	var result2 *Sbm
+	result2, err = NewFromMacPaint(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result2.GetArrayBits(), result.GetArrayBits())
+
+	// Test #3. Image does not fit.
+	err = sbm.WriteMacPaint(new(bytes.Buffer), MacPaintOptions{X: MacPaintWidth - 2})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrMacPaintTooLarge)
+
+	// Test #4. MacBinary without a file name.
+	err = sbm.WriteMacPaint(new(bytes.Buffer), MacPaintOptions{MacBinary: true})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrMacPaintFileName)
+}
+
+func Test_NewFromMacPaint(t *testing.T) {
+
+	var err error
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. Truncated header.
+	_, err = NewFromMacPaint(bytes.NewReader(make([]byte, 100)))
+	tst.MustBeAnError(err)
+
+	// Test #2. Truncated bitmap.
+	_, err = NewFromMacPaint(bytes.NewReader(make([]byte, MacPaintHeaderSize+10)))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrPackBitsData)
+
+	// Test #3. Runs crossing the rows.
+	file := make([]byte, MacPaintHeaderSize)
+	for i := 0; i < MacPaintRowSize*MacPaintHeight/PackBitsRunMax; i++ {
+		file = append(file, 0x81, 0xFF)
+	}
+	sbm, err := NewFromMacPaint(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayBits()[0], bit.Zero)
+	tst.MustBeEqual(sbm.GetArrayBits()[MacPaintWidth*MacPaintHeight-1], bit.Zero)
+}
+
+func Test_crc16Xmodem(t *testing.T) {
+
+	var tst = tester.New(t)
+
+	// Test #1. The check value of the algorithm.
+	tst.MustBeEqual(crc16Xmodem([]byte("123456789")), uint16(0x31C3))
+}
@@ -1,11 +1,10 @@
 package sbm
 
 import (
-	"errors"
 	"io"
+	"math/bits"
 
 	"github.com/vault-thirteen/auxie/bit"
-	rdr "github.com/vault-thirteen/auxie/reader"
 )
 
 // NewFromBitsArray creates a new SBM from an array of bits.
@@ -15,13 +14,14 @@ func NewFromBitsArray(
 	arrayWidth uint, // Width of a 2D-array.
 	arrayHeight uint, // Height of a 2D-array.
 ) (sbm *Sbm, err error) {
-	var arrayArea = arrayWidth * arrayHeight
+	arrayArea, ok := multiplyDimensions(arrayWidth, arrayHeight)
 
 	// Checks.
 	if (arrayWidth == 0) ||
 		(arrayHeight == 0) ||
+		!ok ||
 		(uint(len(arrayBits)) != arrayArea) {
-		return nil, errors.New(ErrDimension)
+		return nil, ErrorDimension
 	}
 
 	return newFromBitsArray(arrayBits, arrayWidth, arrayHeight)
@@ -34,21 +34,30 @@ func NewFromBytesArray(
 	arrayWidth uint, // Width of a 2D-array.
 	arrayHeight uint, // Height of a 2D-array.
 ) (sbm *Sbm, err error) {
-	arrayAreaReal := arrayWidth * arrayHeight
-	arrayBitsCountMax := uint(len(arrayBytes) * bit.BitsPerByte)
+	arrayAreaReal, ok := multiplyDimensions(arrayWidth, arrayHeight)
+	arrayBitsCountMax, ok2 := multiplyDimensions(uint(len(arrayBytes)), bit.BitsPerByte)
 	arrayBitsCountMin := arrayBitsCountMax - (bit.BitsPerByte - 1)
 
 	// Checks.
 	if (arrayWidth == 0) ||
 		(arrayHeight == 0) ||
+		!ok ||
+		!ok2 ||
 		(arrayAreaReal > arrayBitsCountMax) ||
 		(arrayAreaReal < arrayBitsCountMin) {
-		return nil, errors.New(ErrDimension)
+		return nil, ErrorDimension
 	}
 
 	return newFromBytesArray(arrayBytes, arrayWidth, arrayHeight)
 }
 
+// multiplyDimensions multiplies two dimensions. The flag is false when the
+// product overflows the uint type.
+func multiplyDimensions(a uint, b uint) (product uint, ok bool) {
+	hi, lo := bits.Mul(a, b)
+	return lo, hi == 0
+}
+
 // newFromBitsArray creates a new SBM from an array of bits.
 // Does not perform the fool checks.
 func newFromBitsArray(
@@ -108,60 +117,77 @@ func newFromBitsAndBytesArrays(
 		},
 	}
 
-	// Fill the random header fields ...
+	// Fill the random header fields.
+	err = sbm.fillRandomHeaders()
+	if err != nil {
+		return nil, err
+	}
 
+	return sbm, nil
+}
+
+// fillRandomHeaders fills the random parts of all the headers using the
+// dimensions of the pixel array and the split options.
+func (sbm *Sbm) fillRandomHeaders() (err error) {
 	// 1. Width.
 	sbm.pixelArray.metaData.header.width.topLeft,
 		sbm.pixelArray.metaData.header.width.topRight,
-		err = createRandomValuePair(arrayWidth)
+		err = createValuePair(sbm.pixelArray.metaData.width, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sbm.pixelArray.metaData.header.width.bottomLeft,
 		sbm.pixelArray.metaData.header.width.bottomRight,
-		err = createRandomValuePair(arrayWidth)
+		err = createValuePair(sbm.pixelArray.metaData.width, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// 2. Height.
 	sbm.pixelArray.metaData.header.height.topLeft,
 		sbm.pixelArray.metaData.header.height.topRight,
-		err = createRandomValuePair(arrayHeight)
+		err = createValuePair(sbm.pixelArray.metaData.height, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sbm.pixelArray.metaData.header.height.bottomLeft,
 		sbm.pixelArray.metaData.header.height.bottomRight,
-		err = createRandomValuePair(arrayHeight)
+		err = createValuePair(sbm.pixelArray.metaData.height, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// 3. Area.
 	sbm.pixelArray.metaData.header.area.topLeft,
 		sbm.pixelArray.metaData.header.area.topRight,
-		err = createRandomValuePair(arrayArea)
+		err = createValuePair(sbm.pixelArray.metaData.area, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sbm.pixelArray.metaData.header.area.bottomLeft,
 		sbm.pixelArray.metaData.header.area.bottomRight,
-		err = createRandomValuePair(arrayArea)
+		err = createValuePair(sbm.pixelArray.metaData.area, sbm.split)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return sbm, nil
+	return nil
 }
 
 // NewFromStream reads an SBM object from the stream.
 func NewFromStream(reader io.Reader) (sbm *Sbm, err error) {
+	return NewFromStreamWithOptions(reader, DecodeOptions{})
+}
+
+// NewFromStreamWithOptions reads an SBM object from the stream refusing the
+// files which exceed the limits of the options. It is intended for files from
+// untrusted sources.
+func NewFromStreamWithOptions(reader io.Reader, opts DecodeOptions) (sbm *Sbm, err error) {
 	sbm = new(Sbm)
-	lineReader := rdr.New(reader)
+	lineReader := newLineReader(reader)
 
 	// Read the top headers.
-	err = sbm.readTopHeaders(lineReader)
+	err = sbm.readTopHeaders(lineReader, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +199,7 @@ func NewFromStream(reader io.Reader) (sbm *Sbm, err error) {
 	}
 
 	// Read the bottom headers.
-	err = sbm.readBottomHeaders(lineReader)
+	err = sbm.readBottomHeaders(lineReader, opts)
 	if err != nil {
 		return nil, err
 	}
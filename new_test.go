@@ -2,7 +2,10 @@ package sbm
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"math/bits"
+	"strings"
 	"testing"
 
 	"github.com/vault-thirteen/auxie/bit"
@@ -23,6 +26,7 @@ func Test_NewFromBitsArray(t *testing.T) {
 		123,
 	)
 	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorDimension), true)
 
 	// Test #2. Zero Height.
 	_, err = NewFromBitsArray(
@@ -64,6 +68,14 @@ func Test_NewFromBitsArray(t *testing.T) {
 		4,
 	)
 	tst.MustBeNoError(err)
+
+	// Test #5. Area overflows.
+	_, err = NewFromBitsArray(
+		[]bit.Bit{},
+		1<<(bits.UintSize/2),
+		1<<(bits.UintSize/2),
+	)
+	tst.MustBeAnError(err)
 }
 
 func Test_NewFromBytesArray(t *testing.T) {
@@ -533,3 +545,67 @@ func Test_NewFromStream(t *testing.T) {
 	tst.MustBeAnError(err)
 	tst.MustBeEqual(sbm, (*Sbm)(nil))
 }
+
+func Test_NewFromStreamWithOptions(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	top := "SBM (SIMPLE BIT MAP)" + NL + "VERSION 1" + NL
+	data := top + "WIDTH 3 (2 + 1)" + NL + "HEIGHT 4 (3 + 1)" + NL + "AREA 12 (11 + 1)" + NL +
+		"\xFF\xFF" + NL + "WIDTH 3 (0 + 3)" + NL + "HEIGHT 4 (0 + 4)" + NL + "AREA 12 (10 + 2)" + NL
+
+	// Test #1. Limits are not exceeded.
+	sbm, err = NewFromStreamWithOptions(strings.NewReader(data), DecodeOptions{
+		MaxWidth:            3,
+		MaxHeight:           4,
+		MaxArea:             12,
+		MaxHeaderLineLength: 22,
+	})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayArea(), uint(12))
+
+	// Test #2. Limits are exceeded.
+	for _, opts := range []DecodeOptions{
+		{MaxWidth: 2},
+		{MaxHeight: 3},
+		{MaxArea: 11},
+		{MaxHeaderLineLength: 21},
+	} {
+		_, err = NewFromStreamWithOptions(strings.NewReader(data), opts)
+		tst.MustBeAnError(err)
+		tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	}
+
+	// Test #3. A long header line is not read to its end.
+	_, err = NewFromStreamWithOptions(
+		strings.NewReader(top+"WIDTH "+strings.Repeat("1", 1000)),
+		DecodeOptions{MaxHeaderLineLength: 64},
+	)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+
+	// Test #4. Area overflows.
+	_, err = NewFromStream(strings.NewReader(top +
+		"WIDTH 4294967296 (0 + 4294967296)" + NL +
+		"HEIGHT 4294967296 (0 + 4294967296)" + NL +
+		"AREA 0 (0 + 0)" + NL))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorOverflow), true)
+
+	// Test #5. Sum of the random parts overflows.
+	_, err = NewFromStream(strings.NewReader(top + "WIDTH 3 (18446744073709551615 + 4)" + NL))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorIntegrity) || errors.Is(err, ErrorOverflow), true)
+
+	// Test #6. Truncated array with a huge declared area.
+	_, err = NewFromStream(strings.NewReader(top +
+		"WIDTH 1000000 (1 + 999999)" + NL +
+		"HEIGHT 1000000 (1 + 999999)" + NL +
+		"AREA 1000000000000 (1 + 999999999999)" + NL + "\x00\x00"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.ErrUnexpectedEOF), true)
+}
@@ -0,0 +1,84 @@
+package sbm
+
+import (
+	"errors"
+)
+
+// PackBits parameters.
+const (
+	PackBitsRunMax = 128
+	PackBitsNoOp   = -128
+)
+
+// Errors.
+const (
+	ErrPackBitsData = "PackBits data is truncated"
+)
+
+// packBitsEncode compresses data using the PackBits algorithm.
+func packBitsEncode(src []byte) (dst []byte) {
+	for i := 0; i < len(src); {
+
+		// Replicate run.
+		run := 1
+		for (i+run < len(src)) && (run < PackBitsRunMax) && (src[i+run] == src[i]) {
+			run++
+		}
+		if run > 1 {
+			dst = append(dst, byte(int8(1-run)), src[i])
+			i += run
+			continue
+		}
+
+		// Literal run ends where a replicate run of at least two bytes
+		// starts.
+		start := i
+		for (i < len(src)) && (i-start < PackBitsRunMax) {
+			if (i+1 < len(src)) && (src[i+1] == src[i]) {
+				break
+			}
+			i++
+		}
+		dst = append(dst, byte(i-start-1))
+		dst = append(dst, src[start:i]...)
+	}
+
+	return dst
+}
+
+// packBitsDecode decompresses PackBits data until the specified size of
+// output is produced.
+func packBitsDecode(src []byte, size int) (dst []byte, n int, err error) {
+	// Every two bytes of the source produce at most a run of bytes.
+	dst = make([]byte, 0, min(size, len(src)*PackBitsRunMax))
+	for len(dst) < size {
+		if n >= len(src) {
+			return nil, n, errors.New(ErrPackBitsData)
+		}
+		header := int(int8(src[n]))
+		n++
+
+		switch {
+		case header == PackBitsNoOp:
+
+		case header >= 0:
+			count := header + 1
+			if n+count > len(src) {
+				return nil, n, errors.New(ErrPackBitsData)
+			}
+			dst = append(dst, src[n:n+count]...)
+			n += count
+
+		default:
+			if n >= len(src) {
+				return nil, n, errors.New(ErrPackBitsData)
+			}
+			for i := 0; i < 1-header; i++ {
+				dst = append(dst, src[n])
+			}
+			n++
+		}
+	}
+
+	return dst[:size], n, nil
+}
@@ -0,0 +1,67 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_packBitsEncode(t *testing.T) {
+
+	var tst = tester.New(t)
+
+	// Test #1. The example from Apple Technical Note TN1023.
+	tst.MustBeEqual(
+		packBitsEncode([]byte{
+			0xAA, 0xAA, 0xAA, 0x80, 0x00, 0x2A, 0xAA, 0xAA, 0xAA, 0xAA, 0x80,
+			0x00, 0x2A, 0x22, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA,
+			0xAA, 0xAA,
+		}),
+		[]byte{
+			0xFE, 0xAA, 0x02, 0x80, 0x00, 0x2A, 0xFD, 0xAA, 0x03, 0x80, 0x00,
+			0x2A, 0x22, 0xF7, 0xAA,
+		},
+	)
+
+	// Test #2. Long runs are split.
+	tst.MustBeEqual(
+		packBitsEncode(bytes.Repeat([]byte{0x01}, 130)),
+		[]byte{0x81, 0x01, 0xFF, 0x01},
+	)
+
+	// Test #3. Empty data.
+	tst.MustBeEqual(len(packBitsEncode(nil)), 0)
+}
+
+func Test_packBitsDecode(t *testing.T) {
+
+	var err error
+	var n int
+	var result []byte
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. Round trip.
+	src := []byte{}
+	for i := 0; i < 1000; i++ {
+		src = append(src, byte(i/7), byte(i*i))
+	}
+	packed := packBitsEncode(src)
+	result, n, err = packBitsDecode(packed, len(src))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result, src)
+	tst.MustBeEqual(n, len(packed))
+
+	// Test #2. No-operation header is skipped.
+	result, n, err = packBitsDecode([]byte{0x80, 0xFE, 0x07}, 3)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result, []byte{0x07, 0x07, 0x07})
+	tst.MustBeEqual(n, 3)
+
+	// Test #3. Truncated data.
+	_, _, err = packBitsDecode([]byte{0x05, 0x01}, 6)
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrPackBitsData)
+}
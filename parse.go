@@ -11,17 +11,35 @@ import (
 
 // Errors.
 const (
-	ErrFormat            = "format is unrecognized"
-	ErrHeaderSyntax      = "header syntax error"
+	ErrFormat       = "format is unrecognized"
+	ErrHeaderSyntax = "header syntax error"
+
+	// ErrfHeaderUnexpected is the format of the text of an unexpected header
+	// error.
+	//
+	// Deprecated: the errors wrap ErrorHeaderUnexpected, use the errors.Is
+	// function with it instead of comparing the texts.
 	ErrfHeaderUnexpected = "unexpected header: '%v'"
-	ErrOverflow          = "overflow"
-	ErrIntegrity         = "integrity failure"
+
+	ErrHeaderUnexpected = "unexpected header"
+	ErrOverflow         = "overflow"
+	ErrIntegrity        = "integrity failure"
+)
+
+// Sentinel errors, which are comparable with the errors.Is function. Their
+// texts are the texts of the respective ErrXXX constants.
+var (
+	ErrorFormat           = errors.New(ErrFormat)
+	ErrorHeaderSyntax     = errors.New(ErrHeaderSyntax)
+	ErrorHeaderUnexpected = errors.New(ErrHeaderUnexpected)
+	ErrorOverflow         = errors.New(ErrOverflow)
+	ErrorIntegrity        = errors.New(ErrIntegrity)
 )
 
 // parseHeaderFormat parses the format header.
 func (sbm *Sbm) parseHeaderFormat(rawHeader []byte) (err error) {
 	if !bytes.Equal(rawHeader, []byte(Header_FormatName)) {
-		return errors.New(ErrFormat)
+		return ErrorFormat
 	}
 
 	return nil
@@ -41,22 +59,22 @@ func parseHeaderVersion(rawHeader []byte) (headerData HeaderDataVersion, err err
 	headerPartsCountExpected := 2
 	headerParts := strings.Split(string(rawHeaderTrimmed), HeaderPartsSeparator)
 	if len(headerParts) != headerPartsCountExpected {
-		return headerData, errors.New(ErrHeaderSyntax)
+		return headerData, ErrorHeaderSyntax
 	}
 
 	// Check the header name.
 	if headerParts[0] != HeaderPrefix_Version {
-		return headerData, fmt.Errorf(ErrfHeaderUnexpected, headerParts[0])
+		return headerData, fmt.Errorf("%w: '%v'", ErrorHeaderUnexpected, headerParts[0])
 	}
 
 	// Parse the version number.
 	var versionNumberTmp uint64
-	versionNumberTmp, err = strconv.ParseUint(headerParts[1], 10, 64)
+	versionNumberTmp, err = parseHeaderNumber(headerParts[1])
 	if err != nil {
 		return headerData, err
 	}
 	if versionNumberTmp > math.MaxUint8 {
-		return headerData, errors.New(ErrOverflow)
+		return headerData, ErrorOverflow
 	}
 
 	// Save the version number.
@@ -79,17 +97,17 @@ func parseHeaderSize(rawHeader []byte, headerNameExpected string) (headerData He
 	headerPartsCountExpected := 5
 	headerParts := strings.Split(string(rawHeaderTrimmed), HeaderPartsSeparator)
 	if len(headerParts) != headerPartsCountExpected {
-		return headerData, errors.New(ErrHeaderSyntax)
+		return headerData, ErrorHeaderSyntax
 	}
 
 	// Check the header name.
 	if headerParts[0] != headerNameExpected {
-		return headerData, fmt.Errorf(ErrfHeaderUnexpected, headerParts[0])
+		return headerData, fmt.Errorf("%w: '%v'", ErrorHeaderUnexpected, headerParts[0])
 	}
 
 	// Parse the fixed size.
 	var sizeTmp uint64
-	sizeTmp, err = strconv.ParseUint(headerParts[1], 10, 64)
+	sizeTmp, err = parseHeaderNumber(headerParts[1])
 	if err != nil {
 		return headerData, err
 	}
@@ -98,10 +116,10 @@ func parseHeaderSize(rawHeader []byte, headerNameExpected string) (headerData He
 	// Parse the random left size.
 	headerPartSizeLeft := headerParts[2]
 	if !strings.HasPrefix(headerPartSizeLeft, HeaderPartsBracketLeft) {
-		return headerData, errors.New(ErrHeaderSyntax)
+		return headerData, ErrorHeaderSyntax
 	}
 	headerPartSizeLeft = strings.TrimLeft(headerPartSizeLeft, HeaderPartsBracketLeft)
-	sizeTmp, err = strconv.ParseUint(headerPartSizeLeft, 10, 64)
+	sizeTmp, err = parseHeaderNumber(headerPartSizeLeft)
 	if err != nil {
 		return headerData, err
 	}
@@ -109,24 +127,25 @@ func parseHeaderSize(rawHeader []byte, headerNameExpected string) (headerData He
 
 	// Plus sign.
 	if headerParts[3] != HeaderPartsPlus {
-		return headerData, errors.New(ErrHeaderSyntax)
+		return headerData, ErrorHeaderSyntax
 	}
 
 	// Parse the random right size.
 	headerPartSizeRight := headerParts[4]
 	if !strings.HasSuffix(headerPartSizeRight, HeaderPartsBracketRight) {
-		return headerData, errors.New(ErrHeaderSyntax)
+		return headerData, ErrorHeaderSyntax
 	}
 	headerPartSizeRight = strings.TrimRight(headerPartSizeRight, HeaderPartsBracketRight)
-	sizeTmp, err = strconv.ParseUint(headerPartSizeRight, 10, 64)
+	sizeTmp, err = parseHeaderNumber(headerPartSizeRight)
 	if err != nil {
 		return headerData, err
 	}
 	sizeRandomRight := uint(sizeTmp)
 
-	// Verify the integrity of the size.
-	if sizeFixed != (sizeRandomLeft + sizeRandomRight) {
-		return headerData, errors.New(ErrIntegrity)
+	// Verify the integrity of the size. The sum is not calculated to avoid an
+	// overflow.
+	if (sizeRandomLeft > sizeFixed) || (sizeFixed-sizeRandomLeft != sizeRandomRight) {
+		return headerData, ErrorIntegrity
 	}
 
 	// Save the data.
@@ -151,3 +170,16 @@ func parseHeaderHeight(rawHeader []byte) (headerData HeaderDataSize, err error)
 func parseHeaderArea(rawHeader []byte) (headerData HeaderDataSize, err error) {
 	return parseHeaderSize(rawHeader, HeaderPrefix_Area)
 }
+
+// parseHeaderNumber parses a decimal number of a header.
+func parseHeaderNumber(s string) (n uint64, err error) {
+	n, err = strconv.ParseUint(s, 10, strconv.IntSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%w: %w", ErrorOverflow, err)
+		}
+		return 0, fmt.Errorf("%w: %w", ErrorHeaderSyntax, err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,176 @@
+package sbm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PDF document parameters.
+const (
+	PdfHeader           = "%PDF-1.4\n%\xE2\xE3\xCF\xD3\n"
+	PdfTrailerEnd       = "%%EOF\n"
+	PdfPointsPerInch    = 72
+	PdfDpiDefault       = PdfPointsPerInch
+	PdfObjectCatalog    = 1
+	PdfObjectPages      = 2
+	PdfObjectsPerPage   = 3
+	PdfImageName        = "Im0"
+	PdfXrefEntryPattern = "%010d 00000 n \n"
+)
+
+// Errors.
+const (
+	ErrPdfNoPages     = "PDF document has no pages"
+	ErrPdfCompression = "PDF compression is not supported"
+)
+
+// WritePDF writes an SBM object into the stream as a single-page PDF
+// document.
+func (sbm *Sbm) WritePDF(writer io.Writer, opts PdfOptions) (err error) {
+	return WritePDF(writer, []*Sbm{sbm}, opts)
+}
+
+// WritePDF writes SBM objects into the stream as a PDF document having a page
+// for each object. Size of a page is the size of its image at the specified
+// resolution.
+func WritePDF(writer io.Writer, pages []*Sbm, opts PdfOptions) (err error) {
+	if len(pages) == 0 {
+		return errors.New(ErrPdfNoPages)
+	}
+	dpi := opts.DPI
+	if dpi == 0 {
+		dpi = PdfDpiDefault
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(PdfHeader)
+	offsets := make([]int, 0, PdfObjectPages+len(pages)*PdfObjectsPerPage)
+
+	// 1. Catalog.
+	offsets = append(offsets, buf.Len())
+	fmt.Fprintf(buf, "%d 0 obj\n<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", PdfObjectCatalog, PdfObjectPages)
+
+	// 2. Page tree.
+	kids := make([]byte, 0)
+	for i := range pages {
+		kids = fmt.Appendf(kids, "%d 0 R ", pdfPageObject(i))
+	}
+	offsets = append(offsets, buf.Len())
+	fmt.Fprintf(buf, "%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n",
+		PdfObjectPages, bytes.TrimSpace(kids), len(pages))
+
+	// 3. Pages.
+	for i, page := range pages {
+		width := page.pixelArray.metaData.width
+		height := page.pixelArray.metaData.height
+		if (width == 0) || (height == 0) {
+			return ErrorDimension
+		}
+		pageWidth := pdfNumber(float64(width) * PdfPointsPerInch / float64(dpi))
+		pageHeight := pdfNumber(float64(height) * PdfPointsPerInch / float64(dpi))
+
+		// 3.1. Page.
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf,
+			"%d 0 obj\n<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /XObject << /%s %d 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pdfPageObject(i), PdfObjectPages, pageWidth, pageHeight, PdfImageName, pdfPageObject(i)+2, pdfPageObject(i)+1)
+
+		// 3.2. Content.
+		content := fmt.Sprintf("q %s 0 0 %s 0 0 cm /%s Do Q\n", pageWidth, pageHeight, PdfImageName)
+		if opts.ImageMask {
+			content = "0 g " + content
+		}
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n<< /Length %d >>\nstream\n%sendstream\nendobj\n",
+			pdfPageObject(i)+1, len(content), content)
+
+		// 3.3. Image.
+		var image []byte
+		var filter string
+		image, filter, err = page.pdfImageData(opts.Compression)
+		if err != nil {
+			return err
+		}
+		colour := "/ColorSpace /DeviceGray /BitsPerComponent 1"
+		if opts.ImageMask {
+			colour = "/ImageMask true"
+		}
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n<< /Type /XObject /Subtype /Image /Width %d /Height %d %s %s /Length %d >>\nstream\n",
+			pdfPageObject(i)+2, width, height, colour, filter, len(image))
+		buf.Write(image)
+		buf.WriteString("\nendstream\nendobj\n")
+	}
+
+	// 4. Cross-reference table and trailer.
+	xrefOffset := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, PdfXrefEntryPattern, offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%s",
+		len(offsets)+1, PdfObjectCatalog, xrefOffset, PdfTrailerEnd)
+
+	_, err = writer.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pdfImageData returns the compressed image data and the stream filter.
+// Sample value 0 is black and 1 is white both for DeviceGray images and for
+// image masks with the default decode array, as well as in SBM.
+func (sbm *Sbm) pdfImageData(compression byte) (data []byte, filter string, err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+
+	switch compression {
+	case 0, PdfCompressionFlate:
+		buf := new(bytes.Buffer)
+		zw := zlib.NewWriter(buf)
+		for y := uint(0); y < height; y++ {
+			_, err = zw.Write(sbm.getRowBytesMSB(y, false))
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		err = zw.Close()
+		if err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "/Filter /FlateDecode", nil
+
+	case PdfCompressionCCITT:
+		rows := make([][]byte, 0, height)
+		for y := uint(0); y < height; y++ {
+			rows = append(rows, sbm.getRowBytesMSB(y, true))
+		}
+		data, err = ccittEncode(rows, width, ccittModeG4)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = fmt.Sprintf("/Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns %d /Rows %d /EndOfBlock true >>",
+			width, height)
+		return data, filter, nil
+
+	default:
+		return nil, "", errors.New(ErrPdfCompression)
+	}
+}
+
+// pdfPageObject returns the number of the page object. Content and image
+// objects of the page follow it.
+func pdfPageObject(pageIndex int) int {
+	return PdfObjectPages + 1 + pageIndex*PdfObjectsPerPage
+}
+
+// pdfNumber formats a real number for PDF.
+func pdfNumber(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
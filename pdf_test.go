@@ -0,0 +1,112 @@
+package sbm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WritePDF(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var page1 *Sbm
+	var page2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	page1, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+	page2, err = NewFromBitsArray([]bit.Bit{bit.Zero, bit.One}, 1, 2)
+	tst.MustBeNoError(err)
+
+	// Test #1. Two pages, Flate compression.
+	buffer = new(bytes.Buffer)
+	err = WritePDF(buffer, []*Sbm{page1, page2}, PdfOptions{DPI: 144})
+	tst.MustBeNoError(err)
+	doc := buffer.String()
+	tst.MustBeEqual(strings.HasPrefix(doc, "%PDF-1.4\n"), true)
+	tst.MustBeEqual(strings.HasSuffix(doc, PdfTrailerEnd), true)
+	tst.MustBeEqual(strings.Contains(doc, "/Count 2"), true)
+	tst.MustBeEqual(strings.Contains(doc, "/MediaBox [0 0 1.5 1]"), true)
+	tst.MustBeEqual(strings.Contains(doc, "/MediaBox [0 0 0.5 1]"), true)
+	tst.MustBeEqual(strings.Contains(doc, "/ColorSpace /DeviceGray /BitsPerComponent 1"), true)
+	checkPdfXref(tst, doc, 8)
+
+	image := pdfStreamOfObject(tst, doc, 5)
+	zr, err := zlib.NewReader(bytes.NewReader(image))
+	tst.MustBeNoError(err)
+	rows, err := io.ReadAll(zr)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(rows, []byte{0xA0, 0x20})
+
+	// Test #2. Image mask with CCITT compression.
+	buffer = new(bytes.Buffer)
+	err = page1.WritePDF(buffer, PdfOptions{ImageMask: true, Compression: PdfCompressionCCITT})
+	tst.MustBeNoError(err)
+	doc = buffer.String()
+	tst.MustBeEqual(strings.Contains(doc, "/ImageMask true"), true)
+	tst.MustBeEqual(strings.Contains(doc, "/CCITTFaxDecode"), true)
+	tst.MustBeEqual(strings.Contains(doc, "/MediaBox [0 0 3 2]"), true)
+	checkPdfXref(tst, doc, 5)
+
+	image = pdfStreamOfObject(tst, doc, 5)
+	decoded, err := ccittDecode(image, 3, 2, ccittModeG4)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(decoded, [][]byte{{0x40}, {0xC0}})
+
+	// Test #3. No pages.
+	err = WritePDF(new(bytes.Buffer), nil, PdfOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrPdfNoPages)
+
+	// Test #4. Unknown compression.
+	err = page1.WritePDF(new(bytes.Buffer), PdfOptions{Compression: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrPdfCompression)
+}
+
+// checkPdfXref verifies that entries of the cross-reference table point to the
+// objects.
+func checkPdfXref(tst *tester.Test, doc string, objectsCount int) {
+	idx := strings.LastIndex(doc, "startxref\n")
+	tst.MustBeDifferent(idx, -1)
+	xrefOffset, err := strconv.Atoi(strings.Fields(doc[idx+len("startxref\n"):])[0])
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(strings.HasPrefix(doc[xrefOffset:], "xref\n"), true)
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(doc[xrefOffset:], -1)
+	tst.MustBeEqual(len(entries), objectsCount)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(strings.HasPrefix(doc[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), true)
+	}
+}
+
+// pdfStreamOfObject returns the stream data of the object.
+func pdfStreamOfObject(tst *tester.Test, doc string, object int) []byte {
+	start := strings.Index(doc, fmt.Sprintf("\n%d 0 obj\n", object))
+	tst.MustBeDifferent(start, -1)
+	m := regexp.MustCompile(`/Length (\d+) >>\nstream\n`).FindStringSubmatchIndex(doc[start:])
+	tst.MustBeDifferent(len(m), 0)
+	length, err := strconv.Atoi(doc[start+m[2] : start+m[3]])
+	tst.MustBeNoError(err)
+	return []byte(doc[start+m[1] : start+m[1]+length])
+}
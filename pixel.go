@@ -0,0 +1,42 @@
+package sbm
+
+import (
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// getPixel returns a pixel at the specified position.
+// Does not perform the fool checks.
+func (sbm *Sbm) getPixel(x uint, y uint) bit.Bit {
+	return sbm.pixelArray.data.bits[y*sbm.pixelArray.metaData.width+x]
+}
+
+// getRowBytesMSB packs a row of pixels into bytes, where the first pixel of
+// the row is the most significant bit of the first byte. When 'invert' is set,
+// every pixel is inverted. Unused bits of the last byte are zero.
+// Does not perform the fool checks.
+func (sbm *Sbm) getRowBytesMSB(y uint, invert bool) (row []byte) {
+	width := sbm.pixelArray.metaData.width
+	row = make([]byte, (width+bit.BitsPerByte-1)/bit.BitsPerByte)
+	rowBits := sbm.pixelArray.data.bits[y*width : (y+1)*width]
+
+	for x, b := range rowBits {
+		if b != bit.Bit(invert) {
+			row[x/bit.BitsPerByte] |= 0x80 >> (x % bit.BitsPerByte)
+		}
+	}
+
+	return row
+}
+
+// appendRowBitsMSB appends 'width' pixels taken from a row of bytes, where the
+// first pixel of the row is the most significant bit of the first byte. When
+// 'invert' is set, every pixel is inverted.
+// Does not perform the fool checks.
+func appendRowBitsMSB(bits []bit.Bit, row []byte, width uint, invert bool) []bit.Bit {
+	for x := uint(0); x < width; x++ {
+		b := row[x/bit.BitsPerByte]&(0x80>>(x%bit.BitsPerByte)) != 0
+		bits = append(bits, bit.Bit(b != invert))
+	}
+
+	return bits
+}
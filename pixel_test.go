@@ -0,0 +1,56 @@
+package sbm
+
+import (
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_getRowBytesMSB(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.Zero, bit.Zero, bit.Zero, bit.Zero, bit.Zero, bit.Zero, bit.One,
+			bit.Zero, bit.One, bit.One, bit.One, bit.One, bit.One, bit.One, bit.One, bit.Zero,
+		},
+		9,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Normal.
+	tst.MustBeEqual(sbm.getRowBytesMSB(0, false), []byte{0x80, 0x80})
+	tst.MustBeEqual(sbm.getRowBytesMSB(1, false), []byte{0x7F, 0x00})
+
+	// Test #2. Inverted.
+	tst.MustBeEqual(sbm.getRowBytesMSB(0, true), []byte{0x7F, 0x00})
+	tst.MustBeEqual(sbm.getRowBytesMSB(1, true), []byte{0x80, 0x80})
+
+	// Test #3. Pixels.
+	tst.MustBeEqual(sbm.getPixel(8, 0), bit.One)
+	tst.MustBeEqual(sbm.getPixel(8, 1), bit.Zero)
+}
+
+func Test_appendRowBitsMSB(t *testing.T) {
+
+	var tst = tester.New(t)
+
+	// Test #1. Normal.
+	tst.MustBeEqual(
+		appendRowBitsMSB(nil, []byte{0xA0}, 3, false),
+		[]bit.Bit{bit.One, bit.Zero, bit.One},
+	)
+
+	// Test #2. Inverted with an existing prefix.
+	tst.MustBeEqual(
+		appendRowBitsMSB([]bit.Bit{bit.One}, []byte{0xA0}, 3, true),
+		[]bit.Bit{bit.One, bit.Zero, bit.One, bit.Zero},
+	)
+}
@@ -1,9 +1,20 @@
 package sbm
 
 import (
+	crand "crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand/v2"
+
 	"github.com/vault-thirteen/auxie/random"
 )
 
+// Errors.
+const (
+	ErrSplitMode = "unknown split mode"
+)
+
 // createRandomValuePair creates a pair of random values, which have the sum
 // equal to the sum specified.
 func createRandomValuePair(valueSum uint) (valueLeft uint, valueRight uint, err error) {
@@ -15,3 +26,42 @@ func createRandomValuePair(valueSum uint) (valueLeft uint, valueRight uint, err
 	valueRight = valueSum - valueLeft
 	return valueLeft, valueRight, nil
 }
+
+// createValuePair creates a pair of values, which have the sum equal to the
+// sum specified, using the split options.
+func createValuePair(valueSum uint, opts SplitOptions) (valueLeft uint, valueRight uint, err error) {
+	switch opts.Mode {
+	case SplitModeCanonical:
+		valueLeft = valueSum / 2
+
+	case SplitModeRandom:
+		switch {
+		case opts.Source != nil:
+			r := rand.New(opts.Source)
+			if uint64(valueSum) == math.MaxUint64 {
+				valueLeft = uint(r.Uint64())
+			} else {
+				valueLeft = uint(r.Uint64N(uint64(valueSum) + 1))
+			}
+
+		case opts.Random != nil:
+			limit := new(big.Int).SetUint64(uint64(valueSum))
+			limit.Add(limit, big.NewInt(1))
+			var n *big.Int
+			n, err = crand.Int(opts.Random, limit)
+			if err != nil {
+				return valueLeft, valueRight, err
+			}
+			valueLeft = uint(n.Uint64())
+
+		default:
+			return createRandomValuePair(valueSum)
+		}
+
+	default:
+		return valueLeft, valueRight, errors.New(ErrSplitMode)
+	}
+
+	valueRight = valueSum - valueLeft
+	return valueLeft, valueRight, nil
+}
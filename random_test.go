@@ -1,6 +1,9 @@
 package sbm
 
 import (
+	"bytes"
+	"math"
+	"math/rand/v2"
 	"testing"
 
 	"github.com/vault-thirteen/auxie/tester"
@@ -24,3 +27,49 @@ func Test_createRandomValuePair(t *testing.T) {
 		tst.MustBeEqual(valueLeft+valueRight, valueSum)
 	}
 }
+
+func Test_createValuePair(t *testing.T) {
+
+	var err error
+	var tst *tester.Test
+	var valueLeft uint
+	var valueRight uint
+
+	tst = tester.New(t)
+
+	// Test #1. Canonical mode.
+	valueLeft, valueRight, err = createValuePair(7, SplitOptions{Mode: SplitModeCanonical})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(valueLeft, uint(3))
+	tst.MustBeEqual(valueRight, uint(4))
+
+	// Test #2. Pseudo-random generator with a seed.
+	var pairs [2][]uint
+	for i := range pairs {
+		opts := SplitOptions{Source: rand.NewPCG(1, 2)}
+		for j := 0; j < 100; j++ {
+			valueLeft, valueRight, err = createValuePair(100, opts)
+			tst.MustBeNoError(err)
+			tst.MustBeEqual(valueLeft+valueRight, uint(100))
+			pairs[i] = append(pairs[i], valueLeft)
+		}
+	}
+	tst.MustBeEqual(pairs[0], pairs[1])
+
+	// Test #3. Stream of random bytes.
+	valueLeft, valueRight, err = createValuePair(100, SplitOptions{Random: bytes.NewReader([]byte{200, 30})})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(valueLeft+valueRight, uint(100))
+	_, _, err = createValuePair(100, SplitOptions{Random: bytes.NewReader(nil)})
+	tst.MustBeAnError(err)
+
+	// Test #4. Maximum value.
+	valueLeft, valueRight, err = createValuePair(math.MaxUint, SplitOptions{Source: rand.NewPCG(1, 2)})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(valueLeft+valueRight, uint(math.MaxUint))
+
+	// Test #5. Unknown mode.
+	_, _, err = createValuePair(100, SplitOptions{Mode: 99})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrSplitMode)
+}
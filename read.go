@@ -3,6 +3,7 @@ package sbm
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/vault-thirteen/auxie/bit"
 	rdr "github.com/vault-thirteen/auxie/reader"
@@ -13,59 +14,191 @@ const (
 	ErrBottomHeaderMismatch = "bottom header mismatch"
 	ErrBadSeparator         = "bad separator"
 	ErrAreaMismatch         = "area mismatch"
+	ErrLimit                = "limit is exceeded"
 )
 
-func (sbm *Sbm) readTopHeaders(lineReader *rdr.Reader) (err error) {
+// Size of a chunk of the pixel array read at once.
+const (
+	ArrayChunkSize = 64 * 1024
+)
+
+// Sentinel errors.
+var (
+	ErrorBottomHeaderMismatch = errors.New(ErrBottomHeaderMismatch)
+	ErrorBadSeparator         = errors.New(ErrBadSeparator)
+	ErrorAreaMismatch         = errors.New(ErrAreaMismatch)
+	ErrorLimit                = errors.New(ErrLimit)
+)
+
+// HeaderPrefix_Format is the name of the format header used in errors.
+const HeaderPrefix_Format = "SBM"
+
+// headerLine is a header line together with its position in the file.
+type headerLine struct {
+	section ParseSection
+	name    string
+	number  int
+	offset  int64
+	raw     []byte
+}
+
+// headerLineReader reads the next header line.
+type headerLineReader func(section ParseSection, name string, number int) (hl headerLine, err error)
+
+// readHeaderLine reads a header line having the length not exceeding the
+// limit.
+func readHeaderLine(lineReader *rdr.Reader, section ParseSection, name string, number int, maxLength uint) (hl headerLine, err error) {
+	hl = headerLine{
+		section: section,
+		name:    name,
+		number:  number,
+		offset:  readerOffset(lineReader),
+	}
+
+	hl.raw, err = readLineCRLF(lineReader, maxLength)
+	if err != nil {
+		return hl, hl.error(err)
+	}
+
+	return hl, nil
+}
+
+// readLineCRLF reads a line ending with CR and LF. Zero maximum length means
+// no limit.
+func readLineCRLF(lineReader *rdr.Reader, maxLength uint) (line []byte, err error) {
+	if maxLength == 0 {
+		return lineReader.ReadLineEndingWithCRLF()
+	}
+
+	var b byte
+	for {
+		b, err = lineReader.ReadByte()
+		if err != nil {
+			return line, err
+		}
+		line = append(line, b)
+
+		if (b == LF) && (len(line) >= 2) && (line[len(line)-2] == CR) {
+			return line, nil
+		}
+		if uint(len(line)) >= maxLength {
+			return line, errorLineLength(maxLength)
+		}
+	}
+}
+
+// errorLineLength returns an error of a header line which is too long.
+func errorLineLength(maxLength uint) error {
+	return fmt.Errorf("%w: header line is longer than %d", ErrorLimit, maxLength)
+}
+
+// checkDimensions checks the dimensions of an image against the limits and
+// returns its area. Zero limit means no limit.
+func checkDimensions(width uint, height uint, opts DecodeOptions) (area uint, err error) {
+	area, ok := multiplyDimensions(width, height)
+	if !ok {
+		return 0, ErrorOverflow
+	}
+
+	err = checkLimit("width", width, opts.MaxWidth)
+	if err != nil {
+		return 0, err
+	}
+	err = checkLimit("height", height, opts.MaxHeight)
+	if err != nil {
+		return 0, err
+	}
+	err = checkLimit("area", area, opts.MaxArea)
+	if err != nil {
+		return 0, err
+	}
+
+	return area, nil
+}
+
+// checkLimit checks a value against its limit. Zero limit means no limit.
+func checkLimit(name string, value uint, limit uint) (err error) {
+	if (limit != 0) && (value > limit) {
+		return fmt.Errorf("%w: %s %d is greater than %d", ErrorLimit, name, value, limit)
+	}
+
+	return nil
+}
+
+// error wraps an error with the position of the header line.
+func (hl headerLine) error(err error) error {
+	return newParseError(hl.section, hl.name, hl.number, hl.offset, hl.raw, err)
+}
+
+func (sbm *Sbm) readTopHeaders(lineReader *rdr.Reader, opts DecodeOptions) (err error) {
+	return sbm.parseTopHeaders(
+		func(section ParseSection, name string, number int) (hl headerLine, err error) {
+			return readHeaderLine(lineReader, section, name, number, opts.MaxHeaderLineLength)
+		},
+		opts,
+	)
+}
+
+// parseTopHeaders reads the top headers with the reader of lines.
+func (sbm *Sbm) parseTopHeaders(readLine headerLineReader, opts DecodeOptions) (err error) {
 
 	// 1. Format.
-	var line []byte
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	var hl headerLine
+	hl, err = readLine(ParseSectionTopHeader, HeaderPrefix_Format, LineNumberFormat)
 	if err != nil {
 		return err
 	}
-	err = sbm.parseHeaderFormat(line)
+	err = sbm.parseHeaderFormat(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 
 	// 2. Version.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionTopHeader, HeaderPrefix_Version, LineNumberVersion)
 	if err != nil {
 		return err
 	}
 	var headerFormat HeaderDataVersion
-	headerFormat, err = parseHeaderVersion(line)
+	headerFormat, err = parseHeaderVersion(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 	err = validateFormat(headerFormat)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 	sbm.format.version = headerFormat.version
 
 	// 3. Width.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionTopHeader, HeaderPrefix_Width, LineNumberTopWidth)
 	if err != nil {
 		return err
 	}
 	var headerSize HeaderDataSize
-	headerSize, err = parseHeaderWidth(line)
+	headerSize, err = parseHeaderWidth(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
+	}
+	err = checkLimit("width", headerSize.sizeFixed, opts.MaxWidth)
+	if err != nil {
+		return hl.error(err)
 	}
 	sbm.pixelArray.metaData.width = headerSize.sizeFixed
 	sbm.pixelArray.metaData.header.width.topLeft = headerSize.sizeRandomLeft
 	sbm.pixelArray.metaData.header.width.topRight = headerSize.sizeRandomRight
 
 	// 4. Height.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionTopHeader, HeaderPrefix_Height, LineNumberTopHeight)
 	if err != nil {
 		return err
 	}
-	headerSize, err = parseHeaderHeight(line)
+	headerSize, err = parseHeaderHeight(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
+	}
+	err = checkLimit("height", headerSize.sizeFixed, opts.MaxHeight)
+	if err != nil {
+		return hl.error(err)
 	}
 	sbm.pixelArray.metaData.height = headerSize.sizeFixed
 	sbm.pixelArray.metaData.header.height.topLeft = headerSize.sizeRandomLeft
@@ -74,18 +207,26 @@ func (sbm *Sbm) readTopHeaders(lineReader *rdr.Reader) (err error) {
 	// 5. Area...
 
 	// 5.1. Get the Data.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionTopHeader, HeaderPrefix_Area, LineNumberTopArea)
 	if err != nil {
 		return err
 	}
-	headerSize, err = parseHeaderArea(line)
+	headerSize, err = parseHeaderArea(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 
 	// 5.2. Verify the Data.
-	if sbm.pixelArray.metaData.width*sbm.pixelArray.metaData.height != headerSize.sizeFixed {
-		return errors.New(ErrAreaMismatch)
+	area, ok := multiplyDimensions(sbm.pixelArray.metaData.width, sbm.pixelArray.metaData.height)
+	if !ok {
+		return hl.error(ErrorOverflow)
+	}
+	if area != headerSize.sizeFixed {
+		return hl.error(ErrorAreaMismatch)
+	}
+	err = checkLimit("area", area, opts.MaxArea)
+	if err != nil {
+		return hl.error(err)
 	}
 
 	// 5.3. Save the Data.
@@ -97,30 +238,24 @@ func (sbm *Sbm) readTopHeaders(lineReader *rdr.Reader) (err error) {
 }
 
 func (sbm *Sbm) readArrayData(lineReader *rdr.Reader) (err error) {
-
-	// Exception Handler.
-	defer func() {
-		xErr := recover()
-		if xErr != nil {
-			err = fmt.Errorf("xErr:%v.", xErr)
-		}
-	}()
+	offset := readerOffset(lineReader)
 
 	// Get the Size of Array to know how many Bytes to read.
-	var lastByteIsPartial bool
-	var readBytesSize uint
-	if sbm.pixelArray.metaData.area%bit.BitsPerByte == 0 {
-		readBytesSize = sbm.pixelArray.metaData.area / bit.BitsPerByte
-	} else {
-		lastByteIsPartial = true
-		readBytesSize = (sbm.pixelArray.metaData.area / bit.BitsPerByte) + 1
+	readBytesSize := sbm.arraySize()
+	if readBytesSize > math.MaxInt {
+		return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, ErrorOverflow)
 	}
 
-	// Read the Array.
-	var bytesArray []byte
-	bytesArray, err = lineReader.ReadBytes(int(readBytesSize))
-	if err != nil {
-		return err
+	// Read the Array by chunks, so that the memory is allocated only for the
+	// data which really exists.
+	bytesArray := make([]byte, 0, min(readBytesSize, ArrayChunkSize))
+	for uint(len(bytesArray)) < readBytesSize {
+		var chunk []byte
+		chunk, err = lineReader.ReadBytes(int(min(readBytesSize-uint(len(bytesArray)), ArrayChunkSize)))
+		if err != nil {
+			return newParseError(ParseSectionArray, "", LineNumberArray, offset, nil, err)
+		}
+		bytesArray = append(bytesArray, chunk...)
 	}
 
 	// Read the Separator.
@@ -129,41 +264,64 @@ func (sbm *Sbm) readArrayData(lineReader *rdr.Reader) (err error) {
 		return err
 	}
 
-	// Save the Array...
+	sbm.saveArray(bytesArray)
+
+	return nil
+}
+
+// arraySize returns the size of the pixel array in bytes.
+func (sbm *Sbm) arraySize() (size uint) {
+	size = sbm.pixelArray.metaData.area / bit.BitsPerByte
+	if sbm.pixelArray.metaData.area%bit.BitsPerByte != 0 {
+		size++
+	}
+
+	return size
+}
+
+// saveArray saves the bytes of the pixel array read from a file.
+func (sbm *Sbm) saveArray(bytesArray []byte) {
 
 	// 1. Bits.
 	var bitsArray []bit.Bit
 	bitsArray = bit.ConvertBytesToBits(bytesArray)
-	if lastByteIsPartial {
+	if uint(len(bitsArray)) != sbm.pixelArray.metaData.area {
 		bitsArray = bitsArray[:sbm.pixelArray.metaData.area]
 	}
 	sbm.pixelArray.data.bits = bitsArray
 
 	// 2. Bytes.
 	sbm.pixelArray.data.bytes, _ = bit.ConvertBitsToBytes(bitsArray)
+}
 
-	return nil
+func (sbm *Sbm) readBottomHeaders(lineReader *rdr.Reader, opts DecodeOptions) (err error) {
+	return sbm.parseBottomHeaders(
+		func(section ParseSection, name string, number int) (hl headerLine, err error) {
+			return readHeaderLine(lineReader, section, name, number, opts.MaxHeaderLineLength)
+		},
+	)
 }
 
-func (sbm *Sbm) readBottomHeaders(lineReader *rdr.Reader) (err error) {
+// parseBottomHeaders reads the bottom headers with the reader of lines.
+func (sbm *Sbm) parseBottomHeaders(readLine headerLineReader) (err error) {
 
 	// 1. Width ...
 
 	// 1.1. Get the Data.
-	var line []byte
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	var hl headerLine
+	hl, err = readLine(ParseSectionBottomHeader, HeaderPrefix_Width, LineNumberBottomWidth)
 	if err != nil {
 		return err
 	}
 	var headerSize HeaderDataSize
-	headerSize, err = parseHeaderWidth(line)
+	headerSize, err = parseHeaderWidth(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 
 	// 1.2. Verify the Data.
 	if headerSize.sizeFixed != sbm.pixelArray.metaData.width {
-		return errors.New(ErrBottomHeaderMismatch)
+		return hl.error(ErrorBottomHeaderMismatch)
 	}
 
 	// 1.3. Save the Data.
@@ -173,18 +331,18 @@ func (sbm *Sbm) readBottomHeaders(lineReader *rdr.Reader) (err error) {
 	// 2. Height ...
 
 	// 2.1. Get the Data.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionBottomHeader, HeaderPrefix_Height, LineNumberBottomHeight)
 	if err != nil {
 		return err
 	}
-	headerSize, err = parseHeaderHeight(line)
+	headerSize, err = parseHeaderHeight(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 
 	// 2.2. Verify the Data.
 	if headerSize.sizeFixed != sbm.pixelArray.metaData.height {
-		return errors.New(ErrBottomHeaderMismatch)
+		return hl.error(ErrorBottomHeaderMismatch)
 	}
 
 	// 2.3. Save the Data.
@@ -194,21 +352,21 @@ func (sbm *Sbm) readBottomHeaders(lineReader *rdr.Reader) (err error) {
 	// 3. Area ...
 
 	// 3.1. Get the Data.
-	line, err = lineReader.ReadLineEndingWithCRLF()
+	hl, err = readLine(ParseSectionBottomHeader, HeaderPrefix_Area, LineNumberBottomArea)
 	if err != nil {
 		return err
 	}
-	headerSize, err = parseHeaderArea(line)
+	headerSize, err = parseHeaderArea(hl.raw)
 	if err != nil {
-		return err
+		return hl.error(err)
 	}
 
 	// 3.2. Verify the Data.
 	if headerSize.sizeFixed != sbm.pixelArray.metaData.area {
-		return errors.New(ErrBottomHeaderMismatch)
+		return hl.error(ErrorBottomHeaderMismatch)
 	}
-	if sbm.pixelArray.metaData.width*sbm.pixelArray.metaData.height != headerSize.sizeFixed {
-		return errors.New(ErrAreaMismatch)
+	if area, ok := multiplyDimensions(sbm.pixelArray.metaData.width, sbm.pixelArray.metaData.height); !ok || (area != headerSize.sizeFixed) {
+		return hl.error(ErrorAreaMismatch)
 	}
 
 	// 3.3. Save the Data.
@@ -219,14 +377,16 @@ func (sbm *Sbm) readBottomHeaders(lineReader *rdr.Reader) (err error) {
 }
 
 func readSeparator(lineReader *rdr.Reader) (err error) {
+	offset := readerOffset(lineReader)
+
 	var ba []byte
 	ba, err = lineReader.ReadBytes(2)
 	if err != nil {
-		return err
+		return newParseError(ParseSectionSeparator, "", LineNumberArray, offset, ba, err)
 	}
 
 	if (len(ba) != 2) || (ba[0] != CR) || (ba[1] != LF) {
-		return errors.New(ErrBadSeparator)
+		return newParseError(ParseSectionSeparator, "", LineNumberArray, offset, ba, ErrorBadSeparator)
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package sbm
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -364,7 +365,7 @@ func Test_readTopHeaders(t *testing.T) {
 		// Run the Action.
 		reader = bytes.NewReader(test.data)
 		lineReader = rdr.New(reader)
-		err = test.sbm.readTopHeaders(lineReader)
+		err = test.sbm.readTopHeaders(lineReader, DecodeOptions{})
 
 		// Check.
 		switch test.kind {
@@ -377,7 +378,9 @@ func Test_readTopHeaders(t *testing.T) {
 
 		case TestKindMustBeExactError:
 			tst.MustBeAnError(err)
-			tst.MustBeEqual(err.Error(), test.errorTextExpected)
+			var parseErr *ParseError
+			tst.MustBeEqual(errors.As(err, &parseErr), true)
+			tst.MustBeEqual(parseErr.Err.Error(), test.errorTextExpected)
 
 		default:
 			t.FailNow()
@@ -490,7 +493,7 @@ func Test_readArrayData(t *testing.T) {
 	lineReader = rdr.New(reader)
 	err = sbm.readArrayData(lineReader)
 	tst.MustBeAnError(err)
-	tst.MustBeEqual(err.Error(), ErrBadSeparator)
+	tst.MustBeEqual(errors.Unwrap(err).Error(), ErrBadSeparator)
 	tst.MustBeEqual(*sbm, sbmExpected)
 
 	// Test #4. Negative. Area is too big.
@@ -533,7 +536,7 @@ func Test_readArrayData(t *testing.T) {
 	lineReader = rdr.New(reader)
 	err = sbm.readArrayData(lineReader)
 	tst.MustBeAnError(err)
-	tst.MustBeEqual(err.Error(), io.ErrUnexpectedEOF.Error())
+	tst.MustBeEqual(errors.Unwrap(err).Error(), io.ErrUnexpectedEOF.Error())
 	tst.MustBeEqual(*sbm, sbmExpected)
 }
 
@@ -988,7 +991,7 @@ func Test_readBottomHeaders(t *testing.T) {
 		// Run the Action.
 		reader = bytes.NewReader(test.data)
 		lineReader = rdr.New(reader)
-		err = test.sbm.readBottomHeaders(lineReader)
+		err = test.sbm.readBottomHeaders(lineReader, DecodeOptions{})
 
 		// Check.
 		switch test.kind {
@@ -1001,7 +1004,9 @@ func Test_readBottomHeaders(t *testing.T) {
 
 		case TestKindMustBeExactError:
 			tst.MustBeAnError(err)
-			tst.MustBeEqual(err.Error(), test.errorTextExpected)
+			var parseErr *ParseError
+			tst.MustBeEqual(errors.As(err, &parseErr), true)
+			tst.MustBeEqual(parseErr.Err.Error(), test.errorTextExpected)
 
 		default:
 			t.FailNow()
@@ -1042,3 +1047,52 @@ func Test_readSeparator(t *testing.T) {
 	err = readSeparator(lineReader)
 	tst.MustBeAnError(err)
 }
+
+func Test_ParseError(t *testing.T) {
+
+	var err error
+	var parseErr *ParseError
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	top := "SBM (SIMPLE BIT MAP)" + NL + "VERSION 1" + NL + "WIDTH 3 (1 + 2)" + NL
+
+	// Test #1. Syntax error in a top header.
+	_, err = NewFromStream(bytes.NewReader([]byte(top + "HEIGHT x (1 + 3)" + NL)))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorHeaderSyntax), true)
+	tst.MustBeEqual(errors.As(err, &parseErr), true)
+	tst.MustBeEqual(parseErr.Section, ParseSectionTopHeader)
+	tst.MustBeEqual(parseErr.Header, HeaderPrefix_Height)
+	tst.MustBeEqual(parseErr.Line, LineNumberTopHeight)
+	tst.MustBeEqual(parseErr.Offset, int64(len(top)))
+	tst.MustBeEqual(parseErr.Raw, []byte("HEIGHT x (1 + 3)"+NL))
+	tst.MustBeEqual(err.Error(), fmt.Sprintf(
+		"top header HEIGHT, line 4, offset %d: header syntax error: "+
+			"strconv.ParseUint: parsing \"x\": invalid syntax", len(top)))
+
+	// Test #2. Mismatch in a bottom header.
+	data := top + "HEIGHT 1 (1 + 0)" + NL + "AREA 3 (1 + 2)" + NL + "\x07" + NL +
+		"WIDTH 4 (2 + 2)" + NL
+	_, err = NewFromStream(bytes.NewReader([]byte(data)))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorBottomHeaderMismatch), true)
+	tst.MustBeEqual(errors.As(err, &parseErr), true)
+	tst.MustBeEqual(parseErr.Section, ParseSectionBottomHeader)
+	tst.MustBeEqual(parseErr.Line, LineNumberBottomWidth)
+	tst.MustBeEqual(parseErr.Offset, int64(len(data)-len("WIDTH 4 (2 + 2)"+NL)))
+
+	// Test #3. Bad separator.
+	_, err = NewFromStream(bytes.NewReader([]byte(top + "HEIGHT 1 (1 + 0)" + NL + "AREA 3 (1 + 2)" + NL + "\x07ab")))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorBadSeparator), true)
+	tst.MustBeEqual(errors.As(err, &parseErr), true)
+	tst.MustBeEqual(parseErr.Section, ParseSectionSeparator)
+	tst.MustBeEqual(parseErr.Raw, []byte("ab"))
+
+	// Test #4. Unexpected end of the stream.
+	_, err = NewFromStream(bytes.NewReader([]byte(top)))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, io.EOF), true)
+}
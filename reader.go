@@ -0,0 +1,66 @@
+package sbm
+
+import (
+	"bytes"
+	"io"
+
+	rdr "github.com/vault-thirteen/auxie/reader"
+)
+
+// countingReader is a stream which counts the bytes read.
+type countingReader struct {
+	reader io.Reader
+	offset int64
+}
+
+// Read reads data from the underlying stream.
+func (cr *countingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.reader.Read(p)
+	cr.offset += int64(n)
+	return n, err
+}
+
+// skip skips bytes of the stream, using the io.Seeker interface when the
+// stream has it.
+func (cr *countingReader) skip(n int64) (err error) {
+	if seeker, ok := cr.reader.(io.Seeker); ok {
+		_, err = seeker.Seek(n, io.SeekCurrent)
+	} else {
+		_, err = io.CopyN(io.Discard, cr.reader, n)
+	}
+	if err != nil {
+		return err
+	}
+
+	cr.offset += n
+	return nil
+}
+
+// newLineReader creates a line reader which knows its position in the stream.
+func newLineReader(reader io.Reader) *rdr.Reader {
+	return rdr.New(&countingReader{reader: reader})
+}
+
+// readerOffset returns the position of the line reader in the stream, or -1
+// when the position is unknown.
+func readerOffset(lineReader *rdr.Reader) int64 {
+	cr, ok := lineReader.GetInternalReader().(*countingReader)
+	if !ok {
+		return -1
+	}
+
+	return cr.offset
+}
+
+// newParseError wraps an error with its position in the file. The data read
+// is copied, so that the buffer may be reused.
+func newParseError(section ParseSection, header string, line int, offset int64, raw []byte, err error) error {
+	return &ParseError{
+		Section: section,
+		Header:  header,
+		Line:    line,
+		Offset:  offset,
+		Raw:     bytes.Clone(raw),
+		Err:     err,
+	}
+}
@@ -0,0 +1,96 @@
+package sbm
+
+import (
+	"io"
+	"iter"
+	"os"
+)
+
+// Permissions of a file created by the AppendFile function.
+const AppendFilePermissions = 0644
+
+// NewScanner creates a scanner reading the stream with the options.
+func NewScanner(reader io.Reader, opts DecodeOptions) (s *Scanner) {
+	return &Scanner{
+		decoder: NewDecoder(reader, opts),
+	}
+}
+
+// Scan reads the next object, which is then available through the Sbm
+// method. It returns false when the stream has ended or an error has
+// happened, the error is available through the Err method.
+func (s *Scanner) Scan() bool {
+	if s.isFinished {
+		return false
+	}
+
+	s.sbm, s.err = s.decoder.Decode()
+	if s.err != nil {
+		s.sbm = nil
+		s.isFinished = true
+		if s.err == io.EOF {
+			s.err = nil
+		}
+		return false
+	}
+
+	return true
+}
+
+// Sbm returns the object read by the last call of the Scan method.
+func (s *Scanner) Sbm() *Sbm {
+	return s.sbm
+}
+
+// Err returns the error of reading. It is nil when the stream has ended
+// between objects.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// All returns an iterator over the objects of the stream. An error is
+// yielded as the last pair.
+func (s *Scanner) All() iter.Seq2[*Sbm, error] {
+	return func(yield func(*Sbm, error) bool) {
+		for s.Scan() {
+			if !yield(s.sbm, nil) {
+				return
+			}
+		}
+		if s.err != nil {
+			yield(nil, s.err)
+		}
+	}
+}
+
+// WriteAll writes the objects into the stream one after another, so that
+// they may be read with a Scanner.
+func WriteAll(writer io.Writer, objects iter.Seq[*Sbm], opts EncodeOptions) (err error) {
+	e := NewEncoder(writer, opts)
+	for sbm := range objects {
+		err = e.Encode(sbm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AppendFile appends an object to the end of the file, creating the file
+// when it does not exist. The object is written with a single call.
+func AppendFile(name string, sbm *Sbm, opts EncodeOptions) (err error) {
+	var f *os.File
+	f, err = os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, AppendFilePermissions)
+	if err != nil {
+		return err
+	}
+
+	err = NewEncoder(f, opts).Encode(sbm)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,120 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Scanner(t *testing.T) {
+
+	var err error
+	var s *Scanner
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	var objects []*Sbm
+	for _, size := range [][2]uint{{13, 7}, {5, 3}, {1, 1}} {
+		var sbm *Sbm
+		sbm, err = NewFromBitsArray(diagonalLineBits(size[0], size[1]), size[0], size[1])
+		tst.MustBeNoError(err)
+		objects = append(objects, sbm)
+	}
+	buffer := new(bytes.Buffer)
+	err = WriteAll(buffer, slices.Values(objects), EncodeOptions{})
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+
+	// Test #1. All the objects are read, the stream ends cleanly.
+	s = NewScanner(bytes.NewReader(data), DecodeOptions{})
+	var read []*Sbm
+	for s.Scan() {
+		read = append(read, s.Sbm())
+	}
+	tst.MustBeNoError(s.Err())
+	tst.MustBeEqual(read, objects)
+	tst.MustBeEqual(s.Scan(), false)
+
+	// Test #2. The stream is not read beyond the objects.
+	reader := strings.NewReader(string(data) + "TAIL" + NL)
+	s = NewScanner(io.LimitReader(reader, int64(len(data))), DecodeOptions{})
+	for s.Scan() {
+	}
+	tst.MustBeNoError(s.Err())
+	s = NewScanner(reader, DecodeOptions{})
+	tst.MustBeEqual(s.Scan(), false)
+	tst.MustBeAnError(s.Err())
+	tst.MustBeEqual(errors.Is(s.Err(), ErrorFormat), true)
+
+	// Test #3. Iterator.
+	read = nil
+	for sbm, err := range NewScanner(bytes.NewReader(data), DecodeOptions{}).All() {
+		tst.MustBeNoError(err)
+		read = append(read, sbm)
+	}
+	tst.MustBeEqual(read, objects)
+
+	// Test #4. Truncated object.
+	var errs []error
+	for _, err := range NewScanner(bytes.NewReader(data[:len(data)-3]), DecodeOptions{}).All() {
+		errs = append(errs, err)
+	}
+	tst.MustBeEqual(len(errs), 3)
+	tst.MustBeNoError(errs[1])
+	tst.MustBeEqual(errors.Is(errs[2], io.ErrUnexpectedEOF), true)
+
+	// Test #5. Empty stream.
+	s = NewScanner(bytes.NewReader(nil), DecodeOptions{})
+	tst.MustBeEqual(s.Scan(), false)
+	tst.MustBeNoError(s.Err())
+
+	// Test #6. NewFromStream does not read beyond the object either.
+	reader = strings.NewReader(string(data))
+	for _, sbm := range objects {
+		read, err := NewFromStream(reader)
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(read, sbm)
+	}
+}
+
+func Test_AppendFile(t *testing.T) {
+
+	var err error
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	var objects []*Sbm
+	for _, size := range [][2]uint{{13, 7}, {5, 3}} {
+		var sbm *Sbm
+		sbm, err = NewFromBitsArray(diagonalLineBits(size[0], size[1]), size[0], size[1])
+		tst.MustBeNoError(err)
+		objects = append(objects, sbm)
+	}
+	name := filepath.Join(t.TempDir(), "frames.sbm")
+	for _, sbm := range objects {
+		err = AppendFile(name, sbm, EncodeOptions{})
+		tst.MustBeNoError(err)
+	}
+
+	f, err := os.Open(name)
+	tst.MustBeNoError(err)
+	defer func() {
+		tst.MustBeNoError(f.Close())
+	}()
+
+	var read []*Sbm
+	for sbm, err := range NewScanner(f, DecodeOptions{}).All() {
+		tst.MustBeNoError(err)
+		read = append(read, sbm)
+	}
+	tst.MustBeEqual(read, objects)
+}
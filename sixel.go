@@ -0,0 +1,117 @@
+package sbm
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Sixel parameters.
+const (
+	SixelIntroducer    = "\x1BP0;1;0q"
+	SixelTerminator    = "\x1B\\"
+	SixelBandHeight    = 6
+	SixelCharacterBase = '?'
+	SixelCarriageRet   = '$'
+	SixelNewLine       = '-'
+	SixelRepeat        = '!'
+	SixelRepeatMin     = 4
+	SixelColourWhite   = 0
+	SixelColourBlack   = 1
+	SixelPalette       = "#0;2;100;100;100#1;2;0;0;0"
+)
+
+// sixelColours are the palette entries of pixel values.
+var sixelColours = [...]struct {
+	value bit.Bit
+	index int
+}{
+	{value: bit.One, index: SixelColourWhite},
+	{value: bit.Zero, index: SixelColourBlack},
+}
+
+// WriteSixel writes an SBM object into the stream as a DEC sixel image for
+// terminals supporting the sixel graphics. The image uses a palette of two
+// colours: white and black.
+func (sbm *Sbm) WriteSixel(writer io.Writer, opts SixelOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	scale := max(opts.Scale, 1)
+	outWidth := width * scale
+	outHeight := height * scale
+
+	var sb strings.Builder
+	sb.WriteString(SixelIntroducer)
+	sb.WriteString(fmt.Sprintf("\"1;1;%d;%d", outWidth, outHeight))
+	sb.WriteString(SixelPalette)
+
+	line := make([]byte, outWidth)
+	for y0 := uint(0); y0 < outHeight; y0 += SixelBandHeight {
+		if y0 > 0 {
+			sb.WriteByte(SixelNewLine)
+		}
+
+		written := false
+		for _, colour := range sixelColours {
+			// Sixels of the colour, the top pixel is the least significant bit.
+			empty := true
+			for x := uint(0); x < outWidth; x++ {
+				var sixel byte
+				for dy := uint(0); (dy < SixelBandHeight) && (y0+dy < outHeight); dy++ {
+					if sbm.getPixel(x/scale, (y0+dy)/scale) == colour.value {
+						sixel |= 1 << dy
+					}
+				}
+				line[x] = SixelCharacterBase + sixel
+				empty = empty && (sixel == 0)
+			}
+			if empty {
+				continue
+			}
+
+			if written {
+				sb.WriteByte(SixelCarriageRet)
+			}
+			written = true
+			sb.WriteString(fmt.Sprintf("#%d", colour.index))
+			writeSixelLine(&sb, strings.TrimRight(string(line), string(rune(SixelCharacterBase))), opts.RunLength)
+		}
+	}
+
+	sb.WriteString(SixelTerminator)
+
+	_, err = writer.Write([]byte(sb.String()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeSixelLine writes sixel characters optionally compressing repeated
+// characters.
+func writeSixelLine(sb *strings.Builder, line string, runLength bool) {
+	if !runLength {
+		sb.WriteString(line)
+		return
+	}
+
+	for i := 0; i < len(line); {
+		j := i + 1
+		for (j < len(line)) && (line[j] == line[i]) {
+			j++
+		}
+		if j-i >= SixelRepeatMin {
+			sb.WriteString(fmt.Sprintf("%c%d%c", SixelRepeat, j-i, line[i]))
+		} else {
+			sb.WriteString(line[i:j])
+		}
+		i = j
+	}
+}
@@ -0,0 +1,50 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteSixel(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.One, bit.One, bit.One,
+			bit.Zero, bit.Zero, bit.Zero, bit.Zero, bit.Zero,
+		},
+		5,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. No options.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteSixel(buffer, SixelOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "\x1BP0;1;0q\"1;1;5;2"+SixelPalette+
+		"#0?@@@@$#1BAAAA\x1B\\")
+
+	// Test #2. Run-length compression.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteSixel(buffer, SixelOptions{RunLength: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "\x1BP0;1;0q\"1;1;5;2"+SixelPalette+
+		"#0?!4@$#1B!4A\x1B\\")
+
+	// Test #3. Scaling makes two bands.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteSixel(buffer, SixelOptions{Scale: 4, RunLength: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "\x1BP0;1;0q\"1;1;20;8"+SixelPalette+
+		"#0!4?!16N$#1!4~!16o-#1!20B\x1B\\")
+}
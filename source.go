@@ -0,0 +1,186 @@
+package sbm
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+// Source code generation parameters.
+const (
+	SourceBytesPerLine = 12
+	SourceImportPath   = "github.com/vault-thirteen/SBM"
+	SourceImportName   = "sbm"
+)
+
+// sourceReservedC are the keywords of C and the identifiers used by the
+// generated C header.
+var sourceReservedC = map[string]bool{
+	"auto": true, "break": true, "case": true, "char": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extern": true, "float": true, "for": true, "goto": true,
+	"if": true, "inline": true, "int": true, "long": true, "register": true,
+	"restrict": true, "return": true, "short": true, "signed": true,
+	"sizeof": true, "static": true, "struct": true, "switch": true,
+	"typedef": true, "union": true, "unsigned": true, "void": true,
+	"volatile": true, "while": true, "alignas": true, "alignof": true,
+	"bool": true, "false": true, "true": true, "nullptr": true,
+	"static_assert": true, "thread_local": true, "typeof": true,
+	"uint8_t": true, "PROGMEM": true,
+}
+
+// sourceReservedGo are the predeclared identifiers used by the generated Go
+// source together with the name of the imported package. The keywords of Go
+// are checked separately.
+var sourceReservedGo = map[string]bool{
+	"byte": true, "nil": true, "panic": true, SourceImportName: true,
+}
+
+// Errors.
+const (
+	ErrSourceName   = "identifier is not valid"
+	ErrSourceLayout = "C array layout is not supported"
+)
+
+// WriteCHeader writes an SBM object into the stream as a C header having a
+// constant array of bytes together with width and height macros.
+func (sbm *Sbm) WriteCHeader(writer io.Writer, opts CHeaderOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if !isCIdentifier(opts.Name) {
+		return errors.New(ErrSourceName)
+	}
+
+	// 1. Array data.
+	var data []byte
+	var layout string
+	switch opts.Layout {
+	case CLayoutAdafruitGFX:
+		layout = "Adafruit GFX, MSB first, 1 is black"
+		for y := uint(0); y < height; y++ {
+			data = append(data, sbm.getRowBytesMSB(y, true)...)
+		}
+	case CLayoutXBM:
+		layout = "XBM, LSB first, 1 is black"
+		for y := uint(0); y < height; y++ {
+			for _, b := range sbm.getRowBytesMSB(y, true) {
+				data = append(data, bits.Reverse8(b))
+			}
+		}
+	case CLayoutRaw:
+		layout = "SBM, 0 is black"
+		data = sbm.pixelArray.data.bytes
+	default:
+		return errors.New(ErrSourceLayout)
+	}
+
+	// 2. Header.
+	macro := strings.ToUpper(opts.Name)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("#ifndef %s_H\n#define %s_H\n\n", macro, macro))
+	sb.WriteString("#include <stdint.h>\n")
+	attribute := ""
+	if opts.Progmem {
+		sb.WriteString("#ifdef __AVR__\n#include <avr/pgmspace.h>\n#elif !defined(PROGMEM)\n#define PROGMEM\n#endif\n")
+		attribute = " PROGMEM"
+	}
+	sb.WriteString(fmt.Sprintf("\n#define %s_WIDTH %d\n#define %s_HEIGHT %d\n\n", macro, width, macro, height))
+	sb.WriteString(fmt.Sprintf("// %s.\n", layout))
+	sb.WriteString(fmt.Sprintf("const uint8_t %s[]%s = {\n", opts.Name, attribute))
+	writeSourceBytes(&sb, data, "\t")
+	sb.WriteString("};\n\n")
+	sb.WriteString(fmt.Sprintf("#endif // %s_H\n", macro))
+
+	_, err = writer.Write([]byte(sb.String()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WriteGoSource writes an SBM object into the stream as a Go source file
+// having a package-level variable created with the NewFromBytesArray
+// function. The output is suitable for the 'go:generate' tools.
+func (sbm *Sbm) WriteGoSource(writer io.Writer, opts GoSourceOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if !isGoIdentifier(opts.Package) || (opts.Package == "_") || !isGoIdentifier(opts.Name) {
+		return errors.New(ErrSourceName)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by SBM; DO NOT EDIT.\n\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", opts.Package))
+	sb.WriteString(fmt.Sprintf("import %s %q\n\n", SourceImportName, SourceImportPath))
+	sb.WriteString(fmt.Sprintf("// %s is a %dx%d bitmap.\n", opts.Name, width, height))
+	sb.WriteString(fmt.Sprintf("var %s = func() *%s.Sbm {\n", opts.Name, SourceImportName))
+	sb.WriteString(fmt.Sprintf("\ts, err := %s.NewFromBytesArray([]byte{\n", SourceImportName))
+	writeSourceBytes(&sb, sbm.pixelArray.data.bytes, "\t\t")
+	sb.WriteString(fmt.Sprintf("\t}, %d, %d)\n", width, height))
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn s\n}()\n")
+
+	_, err = writer.Write([]byte(sb.String()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeSourceBytes writes bytes as hexadecimal literals, a fixed number of
+// bytes per an indented line.
+func writeSourceBytes(sb *strings.Builder, data []byte, indent string) {
+	for i := 0; i < len(data); i += SourceBytesPerLine {
+		sb.WriteString(indent)
+		for j, b := range data[i:min(i+SourceBytesPerLine, len(data))] {
+			if j > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(fmt.Sprintf("0x%02X,", b))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// isCIdentifier checks whether the name is an identifier which may be used in
+// the generated C header. Identifiers reserved by the C standard are rejected.
+func isCIdentifier(name string) bool {
+	if strings.HasPrefix(name, "__") ||
+		((len(name) > 1) && (name[0] == '_') && (name[1] >= 'A') && (name[1] <= 'Z')) {
+		return false
+	}
+
+	return isSourceIdentifier(name) && !sourceReservedC[name]
+}
+
+// isGoIdentifier checks whether the name is an identifier which may be used
+// in the generated Go source.
+func isGoIdentifier(name string) bool {
+	return isSourceIdentifier(name) && !token.IsKeyword(name) && !sourceReservedGo[name]
+}
+
+// isSourceIdentifier checks whether the name is an ASCII identifier valid in
+// both C and Go.
+func isSourceIdentifier(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for i, c := range []byte(name) {
+		if (c != '_') && ((c < 'a') || (c > 'z')) && ((c < 'A') || (c > 'Z')) &&
+			((i == 0) || (c < '0') || (c > '9')) {
+			return false
+		}
+	}
+
+	return true
+}
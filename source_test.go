@@ -0,0 +1,127 @@
+package sbm
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteCHeader(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.One,
+			bit.One, bit.Zero, bit.Zero,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Adafruit GFX layout in the program memory.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteCHeader(buffer, CHeaderOptions{Name: "logo", Progmem: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "#ifndef LOGO_H\n#define LOGO_H\n\n"+
+		"#include <stdint.h>\n"+
+		"#ifdef __AVR__\n#include <avr/pgmspace.h>\n#elif !defined(PROGMEM)\n#define PROGMEM\n#endif\n\n"+
+		"#define LOGO_WIDTH 3\n#define LOGO_HEIGHT 2\n\n"+
+		"// Adafruit GFX, MSB first, 1 is black.\n"+
+		"const uint8_t logo[] PROGMEM = {\n\t0x80, 0x60,\n};\n\n"+
+		"#endif // LOGO_H\n")
+
+	// Test #2. XBM layout.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteCHeader(buffer, CHeaderOptions{Name: "logo", Layout: CLayoutXBM})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(strings.Contains(buffer.String(), "const uint8_t logo[] = {\n\t0x01, 0x06,\n};"), true)
+
+	// Test #3. Raw layout.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteCHeader(buffer, CHeaderOptions{Name: "logo", Layout: CLayoutRaw})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(strings.Contains(buffer.String(), "{\n\t0x0E,\n}"), true)
+
+	// Test #4. Bad name.
+	for _, name := range []string{"1logo", "static", "int", "uint8_t", "__logo", "_Logo"} {
+		err = sbm.WriteCHeader(new(bytes.Buffer), CHeaderOptions{Name: name})
+		tst.MustBeAnError(err)
+		tst.MustBeEqual(err.Error(), ErrSourceName)
+	}
+
+	// Test #5. Bad layout.
+	err = sbm.WriteCHeader(new(bytes.Buffer), CHeaderOptions{Name: "logo", Layout: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrSourceLayout)
+}
+
+func Test_WriteGoSource(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(make([]bit.Bit, 100), 10, 10)
+	tst.MustBeNoError(err)
+
+	// Test #1. Output is formatted.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteGoSource(buffer, GoSourceOptions{Package: "icons", Name: "Logo"})
+	tst.MustBeNoError(err)
+	formatted, err := format.Source(buffer.Bytes())
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(string(formatted), buffer.String())
+	tst.MustBeEqual(strings.Contains(buffer.String(), "}, 10, 10)\n"), true)
+	tst.MustBeEqual(strings.Count(buffer.String(), "0x00,"), 13)
+
+	// Test #2. Bad package name.
+	err = sbm.WriteGoSource(new(bytes.Buffer), GoSourceOptions{Package: "my-icons", Name: "Logo"})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrSourceName)
+
+	// Test #3. Reserved words.
+	for _, opts := range []GoSourceOptions{
+		{Package: "func", Name: "Logo"},
+		{Package: "_", Name: "Logo"},
+		{Package: "icons", Name: "type"},
+		{Package: "icons", Name: "sbm"},
+		{Package: "icons", Name: "panic"},
+	} {
+		err = sbm.WriteGoSource(new(bytes.Buffer), opts)
+		tst.MustBeAnError(err)
+		tst.MustBeEqual(err.Error(), ErrSourceName)
+	}
+
+	// Test #4. Output is parsed as Go.
+	for _, opts := range []GoSourceOptions{
+		{Package: "icons", Name: "Logo"},
+		{Package: "main", Name: "static"},
+	} {
+		buffer = new(bytes.Buffer)
+		err = sbm.WriteGoSource(buffer, opts)
+		tst.MustBeNoError(err)
+		var file *ast.File
+		file, err = parser.ParseFile(token.NewFileSet(), "", buffer.Bytes(), 0)
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(file.Name.Name, opts.Package)
+		tst.MustBeEqual(len(file.Decls), 2)
+		tst.MustBeEqual(file.Decls[1].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Names[0].Name, opts.Name)
+	}
+}
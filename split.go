@@ -0,0 +1,36 @@
+package sbm
+
+import (
+	"io"
+)
+
+// SetSplitOptions sets the options of splitting the header values and splits
+// the values again. The options are used by the object until they are changed.
+func (sbm *Sbm) SetSplitOptions(opts SplitOptions) (err error) {
+	previousHeader := sbm.pixelArray.metaData.header
+	previousOptions := sbm.split
+	sbm.split = opts
+
+	err = sbm.fillRandomHeaders()
+	if err != nil {
+		sbm.pixelArray.metaData.header = previousHeader
+		sbm.split = previousOptions
+		return err
+	}
+
+	return nil
+}
+
+// WriteWithSplitOptions writes an SBM object into the stream splitting the
+// header values with the options specified. The object itself is not changed.
+func (sbm *Sbm) WriteWithSplitOptions(writer io.Writer, opts SplitOptions) (err error) {
+	tmp := *sbm
+	tmp.split = opts
+
+	err = tmp.fillRandomHeaders()
+	if err != nil {
+		return err
+	}
+
+	return tmp.Write(writer)
+}
@@ -0,0 +1,77 @@
+package sbm
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_SetSplitOptions(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray([]bit.Bit{bit.Zero, bit.One, bit.One, bit.Zero, bit.One, bit.One}, 3, 2)
+	tst.MustBeNoError(err)
+
+	// Test #1. Canonical mode.
+	err = sbm.SetSplitOptions(SplitOptions{Mode: SplitModeCanonical})
+	tst.MustBeNoError(err)
+	buffer := new(bytes.Buffer)
+	err = sbm.Write(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "SBM (SIMPLE BIT MAP)"+NL+"VERSION 1"+NL+
+		"WIDTH 3 (1 + 2)"+NL+"HEIGHT 2 (1 + 1)"+NL+"AREA 6 (3 + 3)"+NL+"\x36"+NL+
+		"WIDTH 3 (1 + 2)"+NL+"HEIGHT 2 (1 + 1)"+NL+"AREA 6 (3 + 3)"+NL)
+
+	// Test #2. Unknown mode keeps the headers and the options.
+	header := sbm.pixelArray.metaData.header
+	err = sbm.SetSplitOptions(SplitOptions{Mode: 99})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(sbm.pixelArray.metaData.header, header)
+	tst.MustBeEqual(sbm.split, SplitOptions{Mode: SplitModeCanonical})
+	buffer.Reset()
+	err = sbm.Write(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "SBM (SIMPLE BIT MAP)"+NL+"VERSION 1"+NL+
+		"WIDTH 3 (1 + 2)"+NL+"HEIGHT 2 (1 + 1)"+NL+"AREA 6 (3 + 3)"+NL+"\x36"+NL+
+		"WIDTH 3 (1 + 2)"+NL+"HEIGHT 2 (1 + 1)"+NL+"AREA 6 (3 + 3)"+NL)
+}
+
+func Test_WriteWithSplitOptions(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray([]bit.Bit{bit.Zero, bit.One, bit.One, bit.Zero, bit.One, bit.One}, 3, 2)
+	tst.MustBeNoError(err)
+	header := sbm.pixelArray.metaData.header
+
+	// Test #1. Identical files with the same seed.
+	var files [2][]byte
+	for i := range files {
+		buffer := new(bytes.Buffer)
+		err = sbm.WriteWithSplitOptions(buffer, SplitOptions{Source: rand.NewPCG(13, 13)})
+		tst.MustBeNoError(err)
+		files[i] = buffer.Bytes()
+	}
+	tst.MustBeEqual(files[0], files[1])
+	tst.MustBeEqual(sbm.pixelArray.metaData.header, header)
+
+	// Test #2. The file is correct.
+	_, err = NewFromStream(bytes.NewReader(files[0]))
+	tst.MustBeNoError(err)
+
+	// Test #3. Unknown mode.
+	err = sbm.WriteWithSplitOptions(new(bytes.Buffer), SplitOptions{Mode: 99})
+	tst.MustBeAnError(err)
+}
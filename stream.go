@@ -0,0 +1,200 @@
+package sbm
+
+import (
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Errors.
+const (
+	ErrRowSize           = "row size error"
+	ErrRowsCount         = "rows count mismatch"
+	ErrRowWriterIsClosed = "row writer is closed"
+)
+
+// NewRowReader starts reading an SBM object from the stream. It reads the top
+// headers, rows of pixels are read later by the ReadRow and ReadRows methods.
+func NewRowReader(reader io.Reader) (rr *RowReader, err error) {
+	return NewRowReaderWithOptions(reader, DecodeOptions{})
+}
+
+// NewRowReaderWithOptions starts reading an SBM object from the stream
+// refusing the files which exceed the limits of the options. It is intended
+// for files from untrusted sources.
+func NewRowReaderWithOptions(reader io.Reader, opts DecodeOptions) (rr *RowReader, err error) {
+	rr = &RowReader{
+		lineReader: newLineReader(reader),
+		opts:       opts,
+	}
+
+	err = rr.sbm.readTopHeaders(rr.lineReader, rr.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+// Width returns the width of the image.
+func (rr *RowReader) Width() uint {
+	return rr.sbm.pixelArray.metaData.width
+}
+
+// Height returns the height of the image.
+func (rr *RowReader) Height() uint {
+	return rr.sbm.pixelArray.metaData.height
+}
+
+// ReadRow reads the next row of pixels. When the last row is read, the
+// separator and the bottom headers are verified. When all the rows have been
+// read, io.EOF is returned.
+func (rr *RowReader) ReadRow() (row []bit.Bit, err error) {
+	return rr.ReadRows(1)
+}
+
+// ReadRows reads up to 'n' next rows of pixels. Fewer rows are returned at the
+// end of the image. When the last row is read, the separator and the bottom
+// headers are verified. When all the rows have been read, io.EOF is returned.
+func (rr *RowReader) ReadRows(n uint) (rows []bit.Bit, err error) {
+	width := rr.sbm.pixelArray.metaData.width
+	height := rr.sbm.pixelArray.metaData.height
+	if rr.rowsRead >= height {
+		return nil, io.EOF
+	}
+	if n == 0 {
+		return nil, errors.New(ErrRowsCount)
+	}
+
+	n = min(n, height-rr.rowsRead)
+	bitsCount := n * width
+
+	// Rows do not start at a byte boundary, so the bits remaining from the
+	// previous byte are used first.
+	rows = make([]bit.Bit, 0, bitsCount)
+	rows = append(rows, rr.pendingBits...)
+	if uint(len(rows)) < bitsCount {
+		var ba []byte
+		ba, err = rr.lineReader.ReadBytes(int((bitsCount - uint(len(rows)) + bit.BitsPerByte - 1) / bit.BitsPerByte))
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, bit.ConvertBytesToBits(ba)...)
+	}
+	rr.pendingBits = append(rr.pendingBits[:0], rows[bitsCount:]...)
+	rows = rows[:bitsCount]
+	rr.rowsRead += n
+
+	// The rest of the last byte is padding.
+	if rr.rowsRead == height {
+		err = readSeparator(rr.lineReader)
+		if err != nil {
+			return nil, err
+		}
+		err = rr.sbm.readBottomHeaders(rr.lineReader, rr.opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+// NewRowWriter starts writing an SBM object into the stream. It writes the top
+// headers, rows of pixels are written later by the WriteRow and WriteRows
+// methods. The Close method finishes the object.
+func NewRowWriter(writer io.Writer, width uint, height uint) (rw *RowWriter, err error) {
+	area, ok := multiplyDimensions(width, height)
+	if (width == 0) || (height == 0) || !ok {
+		return nil, ErrorDimension
+	}
+
+	rw = &RowWriter{
+		writer: writer,
+	}
+	rw.sbm.format.version = SbmFormatVersion1
+	rw.sbm.pixelArray.metaData.width = width
+	rw.sbm.pixelArray.metaData.height = height
+	rw.sbm.pixelArray.metaData.area = area
+
+	err = rw.sbm.fillRandomHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rw.sbm.writeTopHeaders(writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return rw, nil
+}
+
+// WriteRow writes the next row of pixels.
+func (rw *RowWriter) WriteRow(row []bit.Bit) (err error) {
+	if uint(len(row)) != rw.sbm.pixelArray.metaData.width {
+		return errors.New(ErrRowSize)
+	}
+
+	return rw.WriteRows(row)
+}
+
+// WriteRows writes next rows of pixels. Size of the rows must be a multiple
+// of the image's width.
+func (rw *RowWriter) WriteRows(rows []bit.Bit) (err error) {
+	if rw.isClosed {
+		return errors.New(ErrRowWriterIsClosed)
+	}
+
+	width := rw.sbm.pixelArray.metaData.width
+	if (len(rows) == 0) || (uint(len(rows))%width != 0) {
+		return errors.New(ErrRowSize)
+	}
+	n := uint(len(rows)) / width
+	if rw.rowsWritten+n > rw.sbm.pixelArray.metaData.height {
+		return errors.New(ErrRowsCount)
+	}
+
+	// Only whole bytes are written, the rest waits for the next rows.
+	bits := append(rw.pendingBits, rows...)
+	fullBitsCount := len(bits) / bit.BitsPerByte * bit.BitsPerByte
+	if fullBitsCount > 0 {
+		ba, _ := bit.ConvertBitsToBytes(bits[:fullBitsCount])
+		_, err = rw.writer.Write(ba)
+		if err != nil {
+			return err
+		}
+	}
+	rw.pendingBits = append(make([]bit.Bit, 0, bit.BitsPerByte), bits[fullBitsCount:]...)
+	rw.rowsWritten += n
+
+	return nil
+}
+
+// Close writes the padding of the last byte, the separator and the bottom
+// headers. The underlying stream is not closed.
+func (rw *RowWriter) Close() (err error) {
+	if rw.isClosed {
+		return errors.New(ErrRowWriterIsClosed)
+	}
+	if rw.rowsWritten != rw.sbm.pixelArray.metaData.height {
+		return errors.New(ErrRowsCount)
+	}
+	rw.isClosed = true
+
+	if len(rw.pendingBits) > 0 {
+		ba, _ := bit.ConvertBitsToBytes(rw.pendingBits)
+		_, err = rw.writer.Write(ba)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = rw.writer.Write([]byte(NL))
+	if err != nil {
+		return err
+	}
+
+	return rw.sbm.writeBottomHeaders(rw.writer)
+}
@@ -0,0 +1,143 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_RowReader(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var row []bit.Bit
+	var rr *RowReader
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// 5x3 image, rows start in the middle of bytes.
+	sbm, err = NewFromText(`
+		#..#.
+		.##.#
+		##..#
+	`, TextOptions{})
+	tst.MustBeNoError(err)
+	buffer = new(bytes.Buffer)
+	err = sbm.Write(buffer)
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+
+	// Test #1. Row by row.
+	rr, err = NewRowReader(bytes.NewReader(data))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(rr.Width(), uint(5))
+	tst.MustBeEqual(rr.Height(), uint(3))
+	var bits []bit.Bit
+	for {
+		row, err = rr.ReadRow()
+		if err == io.EOF {
+			break
+		}
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(len(row), 5)
+		bits = append(bits, row...)
+	}
+	tst.MustBeEqual(bits, sbm.GetArrayBits())
+
+	// Test #2. Several rows at once.
+	rr, err = NewRowReader(bytes.NewReader(data))
+	tst.MustBeNoError(err)
+	row, err = rr.ReadRows(2)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(row, sbm.GetArrayBits()[:10])
+	row, err = rr.ReadRows(2)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(row, sbm.GetArrayBits()[10:])
+
+	// Test #3. Truncated bottom header is detected with the last row.
+	rr, err = NewRowReader(bytes.NewReader(data[:len(data)-5]))
+	tst.MustBeNoError(err)
+	_, err = rr.ReadRows(2)
+	tst.MustBeNoError(err)
+	_, err = rr.ReadRow()
+	tst.MustBeAnError(err)
+
+	// Test #4. Limits of the options.
+	_, err = NewRowReaderWithOptions(bytes.NewReader(data), DecodeOptions{MaxArea: 14})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	rr, err = NewRowReaderWithOptions(bytes.NewReader(data), DecodeOptions{MaxArea: 15})
+	tst.MustBeNoError(err)
+	_, err = rr.ReadRows(3)
+	tst.MustBeNoError(err)
+}
+
+func Test_RowWriter(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var rw *RowWriter
+	var sbm *Sbm
+	var sbm2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromText(`
+		#..#.
+		.##.#
+		##..#
+	`, TextOptions{})
+	tst.MustBeNoError(err)
+	bits := sbm.GetArrayBits()
+
+	// Test #1. Rows written one by one and together.
+	buffer = new(bytes.Buffer)
+	rw, err = NewRowWriter(buffer, 5, 3)
+	tst.MustBeNoError(err)
+	err = rw.WriteRow(bits[:5])
+	tst.MustBeNoError(err)
+	err = rw.WriteRows(bits[5:])
+	tst.MustBeNoError(err)
+	err = rw.Close()
+	tst.MustBeNoError(err)
+
+	sbm2, err = NewFromStream(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), bits)
+	tst.MustBeEqual(sbm2.GetArrayBytes(), sbm.GetArrayBytes())
+
+	// Test #2. Bad row size.
+	rw, err = NewRowWriter(new(bytes.Buffer), 5, 3)
+	tst.MustBeNoError(err)
+	err = rw.WriteRow(bits[:4])
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrRowSize)
+
+	// Test #3. Too many rows.
+	err = rw.WriteRows(append(bits, bits[:5]...))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrRowsCount)
+
+	// Test #4. Rows are missing.
+	err = rw.WriteRows(bits[:10])
+	tst.MustBeNoError(err)
+	err = rw.Close()
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrRowsCount)
+
+	// Test #5. Closed writer.
+	err = rw.WriteRows(bits[:5])
+	tst.MustBeNoError(err)
+	err = rw.Close()
+	tst.MustBeNoError(err)
+	err = rw.WriteRows(bits[:5])
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrRowWriterIsClosed)
+}
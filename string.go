@@ -10,17 +10,23 @@ const (
 	ErrHeaderEnding = "header ending syntax error"
 )
 
+// Sentinel errors.
+var (
+	ErrorHeaderSize   = errors.New(ErrHeaderSize)
+	ErrorHeaderEnding = errors.New(ErrHeaderEnding)
+)
+
 func trimHeader(rawHeader []byte) (trimmedHeader []byte, err error) {
 	// Check header's size.
 	if len(rawHeader) < 2 {
-		return trimmedHeader, errors.New(ErrHeaderSize)
+		return trimmedHeader, ErrorHeaderSize
 	}
 
 	// Check header's ending.
 	idxLast := len(rawHeader) - 1
 	if (rawHeader[idxLast-1] != CR) ||
 		(rawHeader[idxLast] != LF) {
-		return trimmedHeader, errors.New(ErrHeaderEnding)
+		return trimmedHeader, ErrorHeaderEnding
 	}
 
 	return rawHeader[0 : idxLast-1], nil
@@ -0,0 +1,293 @@
+package sbm
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// Text rendering parameters.
+const (
+	TextBlackASCII   = '#'
+	TextWhiteASCII   = '.'
+	TextBrailleBase  = 0x2800
+	TextRulerModulus = 10
+)
+
+// Errors.
+const (
+	ErrTextCharacter = "unexpected character"
+	ErrTextRowWidth  = "row width mismatch"
+)
+
+// textStyle describes how a cell of pixels is drawn with a character.
+type textStyle struct {
+	cellWidth  uint
+	cellHeight uint
+
+	// glyph returns the character of a cell having the specified pixels
+	// drawn. A pixel at (x, y) of a cell is the bit number y*cellWidth+x.
+	glyph func(pixels uint) rune
+
+	// border is a set of frame characters: corners (top left, top right,
+	// bottom left, bottom right), horizontal and vertical lines.
+	border [6]rune
+}
+
+var (
+	textBorderASCII   = [6]rune{'+', '+', '+', '+', '-', '|'}
+	textBorderUnicode = [6]rune{'┌', '┐', '└', '┘', '─', '│'}
+	textQuadrants     = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+	textHalfBlocks    = []rune(" ▀▄█")
+
+	// textBrailleDots are the dot bits of Braille patterns in the order of
+	// cell pixels.
+	textBrailleDots = [8]rune{0x01, 0x08, 0x02, 0x10, 0x04, 0x20, 0x40, 0x80}
+)
+
+// textStyles are the supported text styles.
+var textStyles = map[byte]textStyle{
+	TextStyleASCII: {
+		cellWidth:  1,
+		cellHeight: 1,
+		border:     textBorderASCII,
+	},
+	TextStyleHalfBlocks: {
+		cellWidth:  1,
+		cellHeight: 2,
+		glyph:      func(pixels uint) rune { return textHalfBlocks[pixels] },
+		border:     textBorderUnicode,
+	},
+	TextStyleQuadrants: {
+		cellWidth:  2,
+		cellHeight: 2,
+		glyph:      func(pixels uint) rune { return textQuadrants[pixels] },
+		border:     textBorderUnicode,
+	},
+	TextStyleBraille: {
+		cellWidth:  2,
+		cellHeight: 4,
+		glyph: func(pixels uint) rune {
+			r := rune(TextBrailleBase)
+			for i, dot := range textBrailleDots {
+				if pixels&(1<<i) != 0 {
+					r |= dot
+				}
+			}
+			return r
+		},
+		border: textBorderUnicode,
+	},
+}
+
+// Render returns a text representation of the image. Lines of the text are
+// separated with the LF character, the last line does not have it. An image
+// with an unknown style is rendered in the ASCII style.
+func (sbm *Sbm) Render(opts TextOptions) string {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ""
+	}
+
+	style, ok := textStyles[opts.Style]
+	if !ok || (opts.Style == TextStyleASCII) {
+		style = textStyles[TextStyleASCII]
+		black, white := opts.characters()
+		style.glyph = func(pixels uint) rune {
+			if pixels != 0 {
+				return black
+			}
+			return white
+		}
+	}
+	columns := (width + style.cellWidth - 1) / style.cellWidth
+	rows := (height + style.cellHeight - 1) / style.cellHeight
+
+	// Ink is the colour of the drawn pixels.
+	ink := bit.Zero
+	if opts.Invert {
+		ink = bit.One
+	}
+
+	var labelWidth int
+	if opts.Ruler {
+		labelWidth = len(strconv.FormatUint(uint64((rows-1)*style.cellHeight), 10)) + 1
+	}
+	lines := make([]string, 0, rows+3)
+	var sb strings.Builder
+
+	// 1. Ruler and the top border.
+	if opts.Ruler {
+		sb.WriteString(strings.Repeat(" ", labelWidth))
+		if opts.Border {
+			sb.WriteString(" ")
+		}
+		for cx := uint(0); cx < columns; cx++ {
+			sb.WriteString(strconv.FormatUint(uint64(cx*style.cellWidth%TextRulerModulus), 10))
+		}
+		lines = append(lines, sb.String())
+		sb.Reset()
+	}
+	if opts.Border {
+		lines = append(lines, strings.Repeat(" ", labelWidth)+string(style.border[0])+
+			strings.Repeat(string(style.border[4]), int(columns))+string(style.border[1]))
+	}
+
+	// 2. Cells.
+	for cy := uint(0); cy < rows; cy++ {
+		if opts.Ruler {
+			sb.WriteString(fmt.Sprintf("%*d ", labelWidth-1, cy*style.cellHeight))
+		}
+		if opts.Border {
+			sb.WriteRune(style.border[5])
+		}
+		for cx := uint(0); cx < columns; cx++ {
+			var pixels uint
+			for dy := uint(0); dy < style.cellHeight; dy++ {
+				for dx := uint(0); dx < style.cellWidth; dx++ {
+					x, y := cx*style.cellWidth+dx, cy*style.cellHeight+dy
+					if (x < width) && (y < height) && (sbm.getPixel(x, y) == ink) {
+						pixels |= 1 << (dy*style.cellWidth + dx)
+					}
+				}
+			}
+			sb.WriteRune(style.glyph(pixels))
+		}
+		if opts.Border {
+			sb.WriteRune(style.border[5])
+		}
+		lines = append(lines, sb.String())
+		sb.Reset()
+	}
+
+	// 3. Bottom border.
+	if opts.Border {
+		lines = append(lines, strings.Repeat(" ", labelWidth)+string(style.border[2])+
+			strings.Repeat(string(style.border[4]), int(columns))+string(style.border[3]))
+	}
+
+	return strings.Join(lines, string(LF))
+}
+
+// characters returns the characters of black and white pixels in the ASCII
+// style.
+func (opts TextOptions) characters() (black rune, white rune) {
+	black, white = opts.Black, opts.White
+	if black == 0 {
+		black = TextBlackASCII
+	}
+	if white == 0 {
+		white = TextWhiteASCII
+	}
+
+	return black, white
+}
+
+// String returns the image drawn in the ASCII style.
+func (sbm *Sbm) String() string {
+	if sbm == nil {
+		return "<nil>"
+	}
+
+	return sbm.Render(TextOptions{})
+}
+
+// Format implements the fmt.Formatter interface. The 'v' and 's' verbs draw
+// the image in the ASCII style, the '+' flag adds a border and a ruler. The
+// '#v' verb prints the size of the image.
+func (sbm *Sbm) Format(f fmt.State, verb rune) {
+	if sbm == nil {
+		_, _ = fmt.Fprint(f, "<nil>")
+		return
+	}
+
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	switch {
+	case (verb == 'v') && f.Flag('#'):
+		_, _ = fmt.Fprintf(f, "&sbm.Sbm{Width:%d, Height:%d}", width, height)
+	case (verb == 'v') || (verb == 's'):
+		opts := TextOptions{}
+		if f.Flag('+') {
+			opts.Border, opts.Ruler = true, true
+		}
+		_, _ = fmt.Fprint(f, sbm.Render(opts))
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(*sbm.Sbm=%dx%d)", verb, width, height)
+	}
+}
+
+// WriteText writes a text representation of the image into the stream. The
+// text is followed by the LF character.
+func (sbm *Sbm) WriteText(writer io.Writer, opts TextOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	_, err = writer.Write([]byte(sbm.Render(opts) + string(LF)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewFromText creates a new SBM from its text representation in the ASCII
+// style, where every line of the text is a row of pixels. Style, border and
+// ruler options are ignored. The first line is skipped when it is empty, the
+// last line is skipped when it is empty or has only tabs, so that the image
+// may be written as a raw string literal. Leading tabs of lines are ignored
+// unless a tab is a pixel character. Lines may end with CR LF.
+func NewFromText(s string, opts TextOptions) (sbm *Sbm, err error) {
+	black, white := opts.characters()
+	inkBit := bit.Zero
+	if opts.Invert {
+		inkBit = bit.One
+	}
+
+	lines := strings.Split(s, string(LF))
+	firstLine := 1
+	if (len(lines) > 0) && (strings.TrimSuffix(lines[0], string(CR)) == "") {
+		lines = lines[1:]
+		firstLine++
+	}
+	if (len(lines) > 0) && (strings.Trim(lines[len(lines)-1], "\t\r") == "") {
+		lines = lines[:len(lines)-1]
+	}
+
+	var width uint
+	var bits []bit.Bit
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, string(CR))
+		if (black != '\t') && (white != '\t') {
+			line = strings.TrimLeft(line, "\t")
+		}
+
+		var rowWidth uint
+		for _, c := range line {
+			switch c {
+			case black:
+				bits = append(bits, inkBit)
+			case white:
+				bits = append(bits, !inkBit)
+			default:
+				return nil, fmt.Errorf("%s: line %d: %q", ErrTextCharacter, firstLine+i, c)
+			}
+			rowWidth++
+		}
+
+		if i == 0 {
+			width = rowWidth
+		} else if rowWidth != width {
+			return nil, fmt.Errorf("%s: line %d: %d instead of %d", ErrTextRowWidth, firstLine+i, rowWidth, width)
+		}
+	}
+
+	return NewFromBitsArray(bits, width, uint(len(lines)))
+}
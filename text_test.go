@@ -0,0 +1,133 @@
+package sbm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Render(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.Zero, bit.One, bit.One,
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		3,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. ASCII.
+	tst.MustBeEqual(sbm.Render(TextOptions{}), "#..\n.#.\n##.")
+	tst.MustBeEqual(sbm.Render(TextOptions{Invert: true}), ".##\n#.#\n..#")
+
+	// Test #2. Half blocks.
+	tst.MustBeEqual(sbm.Render(TextOptions{Style: TextStyleHalfBlocks}), "▀▄ \n▀▀ ")
+
+	// Test #3. Quadrants.
+	tst.MustBeEqual(sbm.Render(TextOptions{Style: TextStyleQuadrants}), "▚ \n▀ ")
+
+	// Test #4. Braille.
+	tst.MustBeEqual(sbm.Render(TextOptions{Style: TextStyleBraille}), "⠵⠀")
+
+	// Test #5. Border and ruler.
+	tst.MustBeEqual(sbm.Render(TextOptions{Border: true, Ruler: true}),
+		"   012\n  +---+\n0 |#..|\n1 |.#.|\n2 |##.|\n  +---+")
+	tst.MustBeEqual(sbm.Render(TextOptions{Style: TextStyleHalfBlocks, Border: true}),
+		"┌───┐\n│▀▄ │\n│▀▀ │\n└───┘")
+
+	// Test #6. Empty image.
+	tst.MustBeEqual((&Sbm{}).Render(TextOptions{}), "")
+}
+
+func Test_Format(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray([]bit.Bit{bit.Zero, bit.One}, 2, 1)
+	tst.MustBeNoError(err)
+
+	tst.MustBeEqual(sbm.String(), "#.")
+	tst.MustBeEqual(fmt.Sprintf("%v", sbm), "#.")
+	tst.MustBeEqual(fmt.Sprintf("%s", sbm), "#.")
+	tst.MustBeEqual(fmt.Sprintf("%+v", sbm), "   01\n  +--+\n0 |#.|\n  +--+")
+	tst.MustBeEqual(fmt.Sprintf("%#v", sbm), "&sbm.Sbm{Width:2, Height:1}")
+	tst.MustBeEqual(fmt.Sprintf("%d", sbm), "%!d(*sbm.Sbm=2x1)")
+	tst.MustBeEqual(fmt.Sprintf("%v", (*Sbm)(nil)), "<nil>")
+}
+
+func Test_NewFromText(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var sbm2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. Raw string literal.
+	sbm, err = NewFromText(`
+		#..
+		.#.
+		##.
+	`, TextOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayWidth(), uint(3))
+	tst.MustBeEqual(sbm.GetArrayHeight(), uint(3))
+	tst.MustBeEqual(sbm.GetArrayBits(), []bit.Bit{
+		bit.Zero, bit.One, bit.One,
+		bit.One, bit.Zero, bit.One,
+		bit.Zero, bit.Zero, bit.One,
+	})
+
+	// Test #2. Custom characters with CR LF line endings.
+	sbm2, err = NewFromText("X  \r\n X \r\nXX \r\n", TextOptions{Black: 'X', White: ' '})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #3. Inverted digits.
+	sbm2, err = NewFromText("011\n101\n001", TextOptions{Black: '1', White: '0', Invert: true})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #4. Round trip.
+	opts := TextOptions{Black: 'X', White: ' '}
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteText(buffer, opts)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "X  \n X \nXX \n")
+	sbm2, err = NewFromText(buffer.String(), opts)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #5. Ragged row.
+	_, err = NewFromText("\n#.\n#.\n#\n", TextOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTextRowWidth+": line 4: 1 instead of 2")
+
+	// Test #6. Unexpected character.
+	_, err = NewFromText("#.\n#x", TextOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTextCharacter+": line 2: 'x'")
+
+	// Test #7. Empty text.
+	_, err = NewFromText("", TextOptions{})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrDimension)
+}
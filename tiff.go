@@ -0,0 +1,520 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// TIFF file parameters.
+const (
+	TiffByteOrderLE       = "II"
+	TiffByteOrderBE       = "MM"
+	TiffMagic             = 42
+	TiffHeaderSize        = 8
+	TiffIfdEntrySize      = 12
+	TiffResolutionDefault = 72
+	TiffG3Options2D       = 1
+	TiffFillOrderLSB      = 2
+)
+
+// Default limits of the decoded TIFF images. They are used instead of the
+// zero limits of the decode options, because a few bytes of CCITT data may
+// describe an image of any size.
+const (
+	TiffMaxWidthDefault = 1 << 17
+	TiffMaxAreaDefault  = 1 << 28
+)
+
+// TIFF tags.
+const (
+	TiffTagImageWidth                = 256
+	TiffTagImageLength               = 257
+	TiffTagBitsPerSample             = 258
+	TiffTagCompression               = 259
+	TiffTagPhotometricInterpretation = 262
+	TiffTagFillOrder                 = 266
+	TiffTagStripOffsets              = 273
+	TiffTagSamplesPerPixel           = 277
+	TiffTagRowsPerStrip              = 278
+	TiffTagStripByteCounts           = 279
+	TiffTagXResolution               = 282
+	TiffTagYResolution               = 283
+	TiffTagT4Options                 = 292
+	TiffTagT6Options                 = 293
+	TiffTagResolutionUnit            = 296
+)
+
+// TIFF field types.
+const (
+	TiffTypeByte     = 1
+	TiffTypeShort    = 3
+	TiffTypeLong     = 4
+	TiffTypeRational = 5
+)
+
+// Values of the TIFF compression tag.
+const (
+	TiffTagValueCompressionNone     = 1
+	TiffTagValueCompressionCcittRle = 2
+	TiffTagValueCompressionT4       = 3
+	TiffTagValueCompressionT6       = 4
+	TiffTagValueCompressionPackBits = 32773
+)
+
+// Values of the TIFF photometric interpretation tag.
+const (
+	TiffPhotometricWhiteIsZero = 0
+	TiffPhotometricBlackIsZero = 1
+)
+
+// Errors.
+const (
+	ErrTiffHeader      = "TIFF header error"
+	ErrTiffIfd         = "TIFF image file directory error"
+	ErrTiffTagMissing  = "TIFF required tag is missing"
+	ErrTiffNotBilevel  = "TIFF is not a bilevel image"
+	ErrTiffCompression = "TIFF compression is not supported"
+	ErrTiffStrip       = "TIFF strip error"
+	ErrTiffTooLarge    = "image is too large for TIFF"
+)
+
+// tiffEntry is an entry of the TIFF image file directory.
+type tiffEntry struct {
+	tag    uint16
+	typ    uint16
+	values []uint32
+}
+
+// WriteTIFF writes an SBM object into the stream as a bilevel TIFF file with
+// a single strip.
+func (sbm *Sbm) WriteTIFF(writer io.Writer, opts TiffOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if (width > math.MaxUint32) || (height > math.MaxUint32) {
+		return errors.New(ErrTiffTooLarge)
+	}
+
+	// 1. Pixel data.
+	rows := make([][]byte, 0, height)
+	for y := uint(0); y < height; y++ {
+		rows = append(rows, sbm.getRowBytesMSB(y, !opts.BlackIsZero))
+	}
+
+	var data []byte
+	var compressionTag uint32
+	var extraEntries []tiffEntry
+	switch opts.Compression {
+	case 0, TiffCompressionNone:
+		compressionTag = TiffTagValueCompressionNone
+		data = bytes.Join(rows, nil)
+
+	case TiffCompressionPackBits:
+		compressionTag = TiffTagValueCompressionPackBits
+		for _, row := range rows {
+			data = append(data, packBitsEncode(row)...)
+		}
+
+	case TiffCompressionMH:
+		compressionTag = TiffTagValueCompressionCcittRle
+		data, err = ccittEncode(rows, width, ccittModeMH)
+
+	case TiffCompressionG3_1D:
+		compressionTag = TiffTagValueCompressionT4
+		data, err = ccittEncode(rows, width, ccittModeG3_1D)
+		extraEntries = append(extraEntries, tiffEntry{TiffTagT4Options, TiffTypeLong, []uint32{0}})
+
+	case TiffCompressionG3_2D:
+		compressionTag = TiffTagValueCompressionT4
+		data, err = ccittEncode(rows, width, ccittModeG3_2D)
+		extraEntries = append(extraEntries, tiffEntry{TiffTagT4Options, TiffTypeLong, []uint32{TiffG3Options2D}})
+
+	case TiffCompressionG4:
+		compressionTag = TiffTagValueCompressionT6
+		data, err = ccittEncode(rows, width, ccittModeG4)
+		extraEntries = append(extraEntries, tiffEntry{TiffTagT6Options, TiffTypeLong, []uint32{0}})
+
+	default:
+		return errors.New(ErrTiffCompression)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 2. Image file directory.
+	photometric := uint32(TiffPhotometricWhiteIsZero)
+	if opts.BlackIsZero {
+		photometric = TiffPhotometricBlackIsZero
+	}
+	xResolution, yResolution := opts.XResolution, opts.YResolution
+	if xResolution == 0 {
+		xResolution = TiffResolutionDefault
+	}
+	if yResolution == 0 {
+		yResolution = TiffResolutionDefault
+	}
+	resolutionUnit := uint32(opts.ResolutionUnit)
+	if resolutionUnit == 0 {
+		resolutionUnit = TiffResolutionUnitInch
+	}
+
+	entries := []tiffEntry{
+		{TiffTagImageWidth, TiffTypeLong, []uint32{uint32(width)}},
+		{TiffTagImageLength, TiffTypeLong, []uint32{uint32(height)}},
+		{TiffTagBitsPerSample, TiffTypeShort, []uint32{1}},
+		{TiffTagCompression, TiffTypeShort, []uint32{compressionTag}},
+		{TiffTagPhotometricInterpretation, TiffTypeShort, []uint32{photometric}},
+		{TiffTagStripOffsets, TiffTypeLong, []uint32{0}},
+		{TiffTagSamplesPerPixel, TiffTypeShort, []uint32{1}},
+		{TiffTagRowsPerStrip, TiffTypeLong, []uint32{uint32(height)}},
+		{TiffTagStripByteCounts, TiffTypeLong, []uint32{uint32(len(data))}},
+		{TiffTagXResolution, TiffTypeRational, []uint32{xResolution, 1}},
+		{TiffTagYResolution, TiffTypeRational, []uint32{yResolution, 1}},
+		{TiffTagResolutionUnit, TiffTypeShort, []uint32{resolutionUnit}},
+	}
+	entries = append(entries, extraEntries...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].tag < entries[j].tag })
+
+	// File layout: header, directory, out-of-line values, pixel data.
+	ifdSize := 2 + len(entries)*TiffIfdEntrySize + 4
+	extraOffset := TiffHeaderSize + ifdSize
+	extraSize := 0
+	for _, e := range entries {
+		if size := tiffValuesSize(e); size > 4 {
+			extraSize += size
+		}
+	}
+	dataOffset := uint64(extraOffset + extraSize)
+	if dataOffset+uint64(len(data)) > math.MaxUint32 {
+		return errors.New(ErrTiffTooLarge)
+	}
+	for i := range entries {
+		if entries[i].tag == TiffTagStripOffsets {
+			entries[i].values[0] = uint32(dataOffset)
+		}
+	}
+
+	// 3. Output.
+	bo := binary.LittleEndian
+	buf := make([]byte, 0, int(dataOffset))
+	buf = append(buf, TiffByteOrderLE...)
+	buf = bo.AppendUint16(buf, TiffMagic)
+	buf = bo.AppendUint32(buf, TiffHeaderSize)
+	buf = bo.AppendUint16(buf, uint16(len(entries)))
+	var extra []byte
+	for _, e := range entries {
+		buf = bo.AppendUint16(buf, e.tag)
+		buf = bo.AppendUint16(buf, e.typ)
+		count := len(e.values)
+		if e.typ == TiffTypeRational {
+			count /= 2
+		}
+		buf = bo.AppendUint32(buf, uint32(count))
+
+		value := tiffAppendValues(nil, bo, e)
+		if len(value) > 4 {
+			buf = bo.AppendUint32(buf, uint32(extraOffset+len(extra)))
+			extra = append(extra, value...)
+		} else {
+			buf = append(buf, value...)
+			buf = append(buf, make([]byte, 4-len(value))...)
+		}
+	}
+	buf = bo.AppendUint32(buf, 0)
+	buf = append(buf, extra...)
+
+	_, err = writer.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewFromTIFF reads an SBM object from the stream containing a bilevel TIFF
+// file. Only the first image of the file is read. Parameters of the TIFF file
+// are returned together with the object.
+func NewFromTIFF(reader io.Reader) (sbm *Sbm, opts TiffOptions, err error) {
+	return NewFromTIFFWithOptions(reader, DecodeOptions{})
+}
+
+// NewFromTIFFWithOptions reads an SBM object from the stream containing a
+// bilevel TIFF file refusing the images which exceed the limits of the decode
+// options. Only the dimension limits of the decode options are used, zero
+// width and area limits are replaced with the TiffMaxXXXDefault values.
+func NewFromTIFFWithOptions(reader io.Reader, decodeOpts DecodeOptions) (sbm *Sbm, opts TiffOptions, err error) {
+	if decodeOpts.MaxWidth == 0 {
+		decodeOpts.MaxWidth = TiffMaxWidthDefault
+	}
+	if decodeOpts.MaxArea == 0 {
+		decodeOpts.MaxArea = TiffMaxAreaDefault
+	}
+
+	var file []byte
+	file, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, opts, err
+	}
+
+	// 1. Header.
+	if len(file) < TiffHeaderSize {
+		return nil, opts, errors.New(ErrTiffHeader)
+	}
+	var bo binary.ByteOrder
+	switch string(file[0:2]) {
+	case TiffByteOrderLE:
+		bo = binary.LittleEndian
+	case TiffByteOrderBE:
+		bo = binary.BigEndian
+	default:
+		return nil, opts, errors.New(ErrTiffHeader)
+	}
+	if bo.Uint16(file[2:4]) != TiffMagic {
+		return nil, opts, errors.New(ErrTiffHeader)
+	}
+
+	// 2. Image file directory.
+	var entries map[uint16][]uint32
+	entries, err = tiffReadIfd(file, bo, uint64(bo.Uint32(file[4:8])))
+	if err != nil {
+		return nil, opts, err
+	}
+
+	get := func(tag uint16, defaultValue uint32) uint32 {
+		values, ok := entries[tag]
+		if !ok || len(values) == 0 {
+			return defaultValue
+		}
+		return values[0]
+	}
+
+	for _, tag := range []uint16{TiffTagImageWidth, TiffTagImageLength, TiffTagStripOffsets} {
+		if _, ok := entries[tag]; !ok {
+			return nil, opts, errors.New(ErrTiffTagMissing)
+		}
+	}
+	width := uint(get(TiffTagImageWidth, 0))
+	height := uint(get(TiffTagImageLength, 0))
+	if (width == 0) || (height == 0) {
+		return nil, opts, ErrorDimension
+	}
+	_, err = checkDimensions(width, height, decodeOpts)
+	if err != nil {
+		return nil, opts, err
+	}
+	if (get(TiffTagBitsPerSample, 1) != 1) || (get(TiffTagSamplesPerPixel, 1) != 1) {
+		return nil, opts, errors.New(ErrTiffNotBilevel)
+	}
+
+	switch get(TiffTagPhotometricInterpretation, TiffPhotometricWhiteIsZero) {
+	case TiffPhotometricWhiteIsZero:
+	case TiffPhotometricBlackIsZero:
+		opts.BlackIsZero = true
+	default:
+		return nil, opts, errors.New(ErrTiffNotBilevel)
+	}
+
+	var ccittMode byte
+	switch get(TiffTagCompression, TiffTagValueCompressionNone) {
+	case TiffTagValueCompressionNone:
+		opts.Compression = TiffCompressionNone
+	case TiffTagValueCompressionPackBits:
+		opts.Compression = TiffCompressionPackBits
+	case TiffTagValueCompressionCcittRle:
+		opts.Compression, ccittMode = TiffCompressionMH, ccittModeMH
+	case TiffTagValueCompressionT4:
+		opts.Compression, ccittMode = TiffCompressionG3_1D, ccittModeG3_1D
+		if get(TiffTagT4Options, 0)&TiffG3Options2D != 0 {
+			opts.Compression, ccittMode = TiffCompressionG3_2D, ccittModeG3_2D
+		}
+	case TiffTagValueCompressionT6:
+		opts.Compression, ccittMode = TiffCompressionG4, ccittModeG4
+	default:
+		return nil, opts, errors.New(ErrTiffCompression)
+	}
+
+	opts.XResolution = tiffRationalValue(entries[TiffTagXResolution])
+	opts.YResolution = tiffRationalValue(entries[TiffTagYResolution])
+	opts.ResolutionUnit = uint16(get(TiffTagResolutionUnit, TiffResolutionUnitInch))
+
+	// 3. Strips.
+	rowsPerStrip := uint(get(TiffTagRowsPerStrip, math.MaxUint32))
+	if rowsPerStrip == 0 {
+		return nil, opts, errors.New(ErrTiffStrip)
+	}
+	stripOffsets := entries[TiffTagStripOffsets]
+	stripByteCounts := entries[TiffTagStripByteCounts]
+	stripsCount := uint(1)
+	if rowsPerStrip < height {
+		stripsCount = (height + rowsPerStrip - 1) / rowsPerStrip
+	}
+	if (uint(len(stripOffsets)) < stripsCount) || (uint(len(stripByteCounts)) < stripsCount) {
+		return nil, opts, errors.New(ErrTiffStrip)
+	}
+	reverseBits := get(TiffTagFillOrder, 1) == TiffFillOrderLSB
+	stride := (width + bit.BitsPerByte - 1) / bit.BitsPerByte
+
+	// Rows and pixels are collected as the strips are decoded, so that the
+	// memory is allocated only for the data which really exists.
+	var rows [][]byte
+	for s := uint(0); s < stripsCount; s++ {
+		start := uint64(stripOffsets[s])
+		end := start + uint64(stripByteCounts[s])
+		if end > uint64(len(file)) {
+			return nil, opts, errors.New(ErrTiffStrip)
+		}
+		strip := file[start:end]
+		if reverseBits {
+			strip = bytes.Clone(strip)
+			for i, b := range strip {
+				strip[i] = bits.Reverse8(b)
+			}
+		}
+		stripRows := min(rowsPerStrip, height-uint(len(rows)))
+
+		switch opts.Compression {
+		case TiffCompressionNone:
+			if uint64(len(strip)) < uint64(stripRows)*uint64(stride) {
+				return nil, opts, errors.New(ErrTiffStrip)
+			}
+			for y := uint(0); y < stripRows; y++ {
+				rows = append(rows, strip[y*stride:(y+1)*stride])
+			}
+
+		case TiffCompressionPackBits:
+			for y := uint(0); y < stripRows; y++ {
+				var row []byte
+				var n int
+				row, n, err = packBitsDecode(strip, int(stride))
+				if err != nil {
+					return nil, opts, err
+				}
+				rows = append(rows, row)
+				strip = strip[n:]
+			}
+
+		default:
+			var stripData [][]byte
+			stripData, err = ccittDecode(strip, width, stripRows, ccittMode)
+			if err != nil {
+				return nil, opts, err
+			}
+			rows = append(rows, stripData...)
+		}
+	}
+
+	// 4. Pixels.
+	var pixels []bit.Bit
+	for _, row := range rows {
+		pixels = appendRowBitsMSB(pixels, row, width, !opts.BlackIsZero)
+	}
+
+	sbm, err = NewFromBitsArray(pixels, width, height)
+	if err != nil {
+		return nil, opts, err
+	}
+
+	return sbm, opts, nil
+}
+
+// tiffReadIfd reads the image file directory located at the offset.
+func tiffReadIfd(file []byte, bo binary.ByteOrder, offset uint64) (entries map[uint16][]uint32, err error) {
+	if offset+2 > uint64(len(file)) {
+		return nil, errors.New(ErrTiffIfd)
+	}
+	count := uint64(bo.Uint16(file[offset:]))
+	if offset+2+count*TiffIfdEntrySize > uint64(len(file)) {
+		return nil, errors.New(ErrTiffIfd)
+	}
+
+	entries = make(map[uint16][]uint32)
+	for i := uint64(0); i < count; i++ {
+		e := file[offset+2+i*TiffIfdEntrySize:]
+		tag := bo.Uint16(e[0:2])
+		typ := bo.Uint16(e[2:4])
+		n := uint64(bo.Uint32(e[4:8]))
+
+		var size uint64
+		switch typ {
+		case TiffTypeByte:
+			size = 1
+		case TiffTypeShort:
+			size = 2
+		case TiffTypeLong:
+			size = 4
+		case TiffTypeRational:
+			size = 8
+		default:
+			// Fields of other types are not used.
+			continue
+		}
+
+		value := e[8:12]
+		if size*n > 4 {
+			valueOffset := uint64(bo.Uint32(e[8:12]))
+			if valueOffset+size*n > uint64(len(file)) {
+				return nil, errors.New(ErrTiffIfd)
+			}
+			value = file[valueOffset : valueOffset+size*n]
+		}
+
+		values := make([]uint32, 0, n)
+		for j := uint64(0); j < n; j++ {
+			switch typ {
+			case TiffTypeByte:
+				values = append(values, uint32(value[j]))
+			case TiffTypeShort:
+				values = append(values, uint32(bo.Uint16(value[j*2:])))
+			case TiffTypeLong:
+				values = append(values, bo.Uint32(value[j*4:]))
+			case TiffTypeRational:
+				values = append(values, bo.Uint32(value[j*8:]), bo.Uint32(value[j*8+4:]))
+			}
+		}
+		entries[tag] = values
+	}
+
+	return entries, nil
+}
+
+// tiffValuesSize returns the size of values of the entry in bytes.
+func tiffValuesSize(e tiffEntry) int {
+	if e.typ == TiffTypeShort {
+		return 2 * len(e.values)
+	}
+	return 4 * len(e.values)
+}
+
+// tiffAppendValues appends the encoded values of the entry.
+func tiffAppendValues(dst []byte, bo binary.AppendByteOrder, e tiffEntry) []byte {
+	for _, v := range e.values {
+		if e.typ == TiffTypeShort {
+			dst = bo.AppendUint16(dst, uint16(v))
+		} else {
+			dst = bo.AppendUint32(dst, v)
+		}
+	}
+	return dst
+}
+
+// tiffRationalValue converts a rational value into an integer one.
+func tiffRationalValue(values []uint32) uint32 {
+	if (len(values) < 2) || (values[1] == 0) {
+		return 0
+	}
+	return uint32((uint64(values[0]) + uint64(values[1])/2) / uint64(values[1]))
+}
@@ -0,0 +1,221 @@
+package sbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteTIFF(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	sbm, err = NewFromBitsArray(
+		[]bit.Bit{
+			bit.One, bit.Zero, bit.One,
+			bit.Zero, bit.Zero, bit.One,
+		},
+		3,
+		2,
+	)
+	tst.MustBeNoError(err)
+
+	// Test #1. Uncompressed WhiteIsZero data.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteTIFF(buffer, TiffOptions{XResolution: 300, YResolution: 300})
+	tst.MustBeNoError(err)
+	data := buffer.Bytes()
+	tst.MustBeEqual(string(data[0:2]), TiffByteOrderLE)
+	tst.MustBeEqual(binary.LittleEndian.Uint16(data[2:4]), uint16(TiffMagic))
+	tst.MustBeEqual(data[len(data)-2:], []byte{0x40, 0xC0})
+
+	entries, err := tiffReadIfd(data, binary.LittleEndian, TiffHeaderSize)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(entries[TiffTagImageWidth], []uint32{3})
+	tst.MustBeEqual(entries[TiffTagImageLength], []uint32{2})
+	tst.MustBeEqual(entries[TiffTagPhotometricInterpretation], []uint32{TiffPhotometricWhiteIsZero})
+	tst.MustBeEqual(entries[TiffTagXResolution], []uint32{300, 1})
+	tst.MustBeEqual(entries[TiffTagResolutionUnit], []uint32{TiffResolutionUnitInch})
+
+	// Test #2. Uncompressed BlackIsZero data.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteTIFF(buffer, TiffOptions{BlackIsZero: true})
+	tst.MustBeNoError(err)
+	data = buffer.Bytes()
+	tst.MustBeEqual(data[len(data)-2:], []byte{0xA0, 0x20})
+
+	// Test #3. Photometric interpretation applies to CCITT data as well.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteTIFF(buffer, TiffOptions{Compression: TiffCompressionG4, BlackIsZero: true})
+	tst.MustBeNoError(err)
+	data = buffer.Bytes()
+	entries, err = tiffReadIfd(data, binary.LittleEndian, TiffHeaderSize)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(entries[TiffTagPhotometricInterpretation], []uint32{TiffPhotometricBlackIsZero})
+	offset, size := entries[TiffTagStripOffsets][0], entries[TiffTagStripByteCounts][0]
+	rows, err := ccittDecode(data[offset:offset+size], 3, 2, ccittModeG4)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(rows, [][]byte{{0xA0}, {0x20}})
+	result, opts, err := NewFromTIFF(bytes.NewReader(data))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(opts.BlackIsZero, true)
+	tst.MustBeEqual(result.GetArrayBits(), sbm.GetArrayBits())
+
+	// Test #4. Unknown compression.
+	err = sbm.WriteTIFF(new(bytes.Buffer), TiffOptions{Compression: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTiffCompression)
+}
+
+func Test_NewFromTIFF(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var opts TiffOptions
+	var result *Sbm
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	const width, height = 131, 17
+	bits := make([]bit.Bit, 0, width*height)
+	for i := 0; i < width*height; i++ {
+		bits = append(bits, bit.Bit((i/5+i/width)%3 != 0))
+	}
+	sbm, err = NewFromBitsArray(bits, width, height)
+	tst.MustBeNoError(err)
+
+	// Test #1. Round trip with all the compressions.
+	compressions := []byte{
+		TiffCompressionNone,
+		TiffCompressionPackBits,
+		TiffCompressionMH,
+		TiffCompressionG3_1D,
+		TiffCompressionG3_2D,
+		TiffCompressionG4,
+	}
+	for _, compression := range compressions {
+		for _, blackIsZero := range []bool{false, true} {
+			optsExpected := TiffOptions{
+				Compression:    compression,
+				BlackIsZero:    blackIsZero,
+				XResolution:    200,
+				YResolution:    100,
+				ResolutionUnit: TiffResolutionUnitCentimeter,
+			}
+			buffer = new(bytes.Buffer)
+			err = sbm.WriteTIFF(buffer, optsExpected)
+			tst.MustBeNoError(err)
+
+			result, opts, err = NewFromTIFF(buffer)
+			tst.MustBeNoError(err)
+			tst.MustBeEqual(opts, optsExpected)
+			tst.MustBeEqual(result.GetArrayWidth(), sbm.GetArrayWidth())
+			tst.MustBeEqual(result.GetArrayHeight(), sbm.GetArrayHeight())
+			tst.MustBeEqual(result.GetArrayBits(), sbm.GetArrayBits())
+		}
+	}
+
+	// Test #2. Big-endian file with two strips and the reversed fill order.
+	file := []byte{'M', 'M', 0, 42, 0, 0, 0, 8}
+	entries := [][3]uint32{
+		{TiffTagImageWidth, TiffTypeShort, 3},
+		{TiffTagImageLength, TiffTypeShort, 2},
+		{TiffTagCompression, TiffTypeShort, TiffTagValueCompressionNone},
+		{TiffTagPhotometricInterpretation, TiffTypeShort, TiffPhotometricBlackIsZero},
+		{TiffTagFillOrder, TiffTypeShort, TiffFillOrderLSB},
+		{TiffTagStripOffsets, TiffTypeShort, 0},
+		{TiffTagRowsPerStrip, TiffTypeShort, 1},
+		{TiffTagStripByteCounts, TiffTypeShort, 0},
+	}
+	dataOffset := uint32(TiffHeaderSize + 2 + len(entries)*TiffIfdEntrySize + 4)
+	file = binary.BigEndian.AppendUint16(file, uint16(len(entries)))
+	for _, e := range entries {
+		file = binary.BigEndian.AppendUint16(file, uint16(e[0]))
+		file = binary.BigEndian.AppendUint16(file, uint16(e[1]))
+		switch e[0] {
+		case TiffTagStripOffsets:
+			file = binary.BigEndian.AppendUint32(file, 2)
+			file = binary.BigEndian.AppendUint16(file, uint16(dataOffset))
+			file = binary.BigEndian.AppendUint16(file, uint16(dataOffset+1))
+		case TiffTagStripByteCounts:
+			file = binary.BigEndian.AppendUint32(file, 2)
+			file = binary.BigEndian.AppendUint16(file, 1)
+			file = binary.BigEndian.AppendUint16(file, 1)
+		default:
+			file = binary.BigEndian.AppendUint32(file, 1)
+			file = binary.BigEndian.AppendUint16(file, uint16(e[2]))
+			file = binary.BigEndian.AppendUint16(file, 0)
+		}
+	}
+	file = binary.BigEndian.AppendUint32(file, 0)
+	file = append(file, 0x05, 0x04)
+
+	result, opts, err = NewFromTIFF(bytes.NewReader(file))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(opts.BlackIsZero, true)
+	tst.MustBeEqual(result.GetArrayBits(), []bit.Bit{
+		bit.One, bit.Zero, bit.One,
+		bit.Zero, bit.Zero, bit.One,
+	})
+
+	// Test #3. Bad header.
+	_, _, err = NewFromTIFF(bytes.NewReader([]byte("XX\x2a\x00\x08\x00\x00\x00")))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTiffHeader)
+
+	// Test #4. Strip is out of the file.
+	_, _, err = NewFromTIFF(bytes.NewReader(file[:len(file)-1]))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTiffStrip)
+
+	// Test #5. Malformed dimensions.
+	huge := bytes.Clone(file)
+	for _, i := range []int{0, 1, 6} {
+		entry := huge[TiffHeaderSize+2+i*TiffIfdEntrySize:]
+		binary.BigEndian.PutUint16(entry[2:4], TiffTypeLong)
+		binary.BigEndian.PutUint32(entry[8:12], math.MaxUint32)
+	}
+	_, _, err = NewFromTIFF(bytes.NewReader(huge))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	_, _, err = NewFromTIFFWithOptions(bytes.NewReader(huge), DecodeOptions{MaxArea: 1 << 20})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	_, _, err = NewFromTIFFWithOptions(bytes.NewReader(huge), DecodeOptions{MaxWidth: math.MaxUint32, MaxArea: math.MaxUint})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrTiffStrip)
+
+	// Test #6. Oversized header of a tiny G4 strip.
+	sbm, err = NewFromBitsArray([]bit.Bit{bit.One, bit.One, bit.One, bit.One}, 2, 2)
+	tst.MustBeNoError(err)
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteTIFF(buffer, TiffOptions{Compression: TiffCompressionG4})
+	tst.MustBeNoError(err)
+	setEntry := func(file []byte, i int, value uint32) {
+		binary.LittleEndian.PutUint32(file[TiffHeaderSize+2+i*TiffIfdEntrySize+8:], value)
+	}
+	huge = bytes.Clone(buffer.Bytes())
+	setEntry(huge, 0, math.MaxUint32)
+	_, _, err = NewFromTIFF(bytes.NewReader(huge))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+	huge = bytes.Clone(buffer.Bytes())
+	setEntry(huge, 0, TiffMaxWidthDefault)
+	setEntry(huge, 1, TiffMaxAreaDefault/TiffMaxWidthDefault)
+	setEntry(huge, 7, math.MaxUint32)
+	_, _, err = NewFromTIFF(bytes.NewReader(huge))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrCcittData)
+}
@@ -8,9 +8,14 @@ const (
 	ErrVersion = "version error"
 )
 
+// Sentinel errors.
+var (
+	ErrorVersion = errors.New(ErrVersion)
+)
+
 func validateFormat(headerFormat HeaderDataVersion) (err error) {
 	if headerFormat.version != SbmFormatVersion1 {
-		return errors.New(ErrVersion)
+		return ErrorVersion
 	}
 
 	return nil
@@ -0,0 +1,154 @@
+package sbm
+
+import (
+	"errors"
+	"io"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// WBMP file parameters.
+const (
+	WbmpType0             = 0
+	WbmpFixHeader         = 0
+	WbmpMbiContinuation   = 0x80
+	WbmpMbiValueMask      = 0x7F
+	WbmpMbiValueBits      = 7
+	WbmpMbiSizeMax        = 3
+	WbmpDimensionMax      = 1<<(WbmpMbiValueBits*WbmpMbiSizeMax) - 1
+	WbmpFixHeaderExtended = 0x80
+)
+
+// Errors.
+const (
+	ErrWbmpType      = "WBMP type is not supported"
+	ErrWbmpExtHeader = "WBMP extension headers are not supported"
+	ErrWbmpMbi       = "WBMP multi-byte integer error"
+	ErrWbmpTooLarge  = "image is too large for WBMP"
+)
+
+// WriteWBMP writes an SBM object into the stream as a WBMP image of type 0.
+// Bit values of SBM and of WBMP are the same: zero is black and one is white.
+func (sbm *Sbm) WriteWBMP(writer io.Writer) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+	if (width > WbmpDimensionMax) || (height > WbmpDimensionMax) {
+		return errors.New(ErrWbmpTooLarge)
+	}
+
+	// 1. Headers.
+	header := appendWbmpMbi(nil, WbmpType0)
+	header = append(header, WbmpFixHeader)
+	header = appendWbmpMbi(header, width)
+	header = appendWbmpMbi(header, height)
+	_, err = writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	// 2. Pixels.
+	for y := uint(0); y < height; y++ {
+		_, err = writer.Write(sbm.getRowBytesMSB(y, false))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewFromWBMP reads an SBM object from the stream containing a WBMP image of
+// type 0.
+func NewFromWBMP(reader io.Reader) (sbm *Sbm, err error) {
+
+	// 1. Headers.
+	var typeField uint
+	typeField, err = readWbmpMbi(reader)
+	if err != nil {
+		return nil, err
+	}
+	if typeField != WbmpType0 {
+		return nil, errors.New(ErrWbmpType)
+	}
+
+	fixHeader := make([]byte, 1)
+	_, err = io.ReadFull(reader, fixHeader)
+	if err != nil {
+		return nil, err
+	}
+	if fixHeader[0]&WbmpFixHeaderExtended != 0 {
+		return nil, errors.New(ErrWbmpExtHeader)
+	}
+
+	var width, height uint
+	width, err = readWbmpMbi(reader)
+	if err != nil {
+		return nil, err
+	}
+	height, err = readWbmpMbi(reader)
+	if err != nil {
+		return nil, err
+	}
+	if (width == 0) || (height == 0) {
+		return nil, ErrorDimension
+	}
+
+	// 2. Pixels.
+	var bits []bit.Bit
+	row := make([]byte, (width+bit.BitsPerByte-1)/bit.BitsPerByte)
+	for y := uint(0); y < height; y++ {
+		_, err = io.ReadFull(reader, row)
+		if err != nil {
+			return nil, err
+		}
+		bits = appendRowBitsMSB(bits, row, width, false)
+	}
+
+	return NewFromBitsArray(bits, width, height)
+}
+
+// appendWbmpMbi appends a multi-byte integer, which stores seven bits of the
+// value in each byte starting with the most significant group.
+func appendWbmpMbi(dst []byte, value uint) []byte {
+	var groups []byte
+	for {
+		groups = append(groups, byte(value&WbmpMbiValueMask))
+		value >>= WbmpMbiValueBits
+		if value == 0 {
+			break
+		}
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		b := groups[i]
+		if i > 0 {
+			b |= WbmpMbiContinuation
+		}
+		dst = append(dst, b)
+	}
+
+	return dst
+}
+
+// readWbmpMbi reads a multi-byte integer. Integers longer than the maximum
+// size are rejected, so that a malformed stream can not cause an overflow or
+// a huge allocation.
+func readWbmpMbi(reader io.Reader) (value uint, err error) {
+	b := make([]byte, 1)
+	for i := 0; i < WbmpMbiSizeMax; i++ {
+		_, err = io.ReadFull(reader, b)
+		if err != nil {
+			return 0, err
+		}
+
+		value = value<<WbmpMbiValueBits | uint(b[0]&WbmpMbiValueMask)
+		if b[0]&WbmpMbiContinuation == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, errors.New(ErrWbmpMbi)
+}
@@ -0,0 +1,123 @@
+package sbm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteWBMP(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var result *Sbm
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	bits := make([]bit.Bit, 0, 200*3)
+	for i := 0; i < 200*3; i++ {
+		bits = append(bits, bit.Bit(i%7 == 0))
+	}
+	sbm, err = NewFromBitsArray(bits, 200, 3)
+	tst.MustBeNoError(err)
+
+	// Test #1. Headers.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteWBMP(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.Bytes()[:5], []byte{0x00, 0x00, 0x81, 0x48, 0x03})
+	tst.MustBeEqual(buffer.Len(), 5+25*3)
+
+	// Test #2. Round trip.
+	result, err = NewFromWBMP(buffer)
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result.GetArrayWidth(), uint(200))
+	tst.MustBeEqual(result.GetArrayHeight(), uint(3))
+	tst.MustBeEqual(result.GetArrayBits(), sbm.GetArrayBits())
+}
+
+func Test_NewFromWBMP(t *testing.T) {
+
+	var err error
+	var result *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. Normal image.
+	result, err = NewFromWBMP(bytes.NewReader([]byte{0x00, 0x00, 0x03, 0x02, 0xA0, 0x20}))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(result.GetArrayBits(), []bit.Bit{
+		bit.One, bit.Zero, bit.One,
+		bit.Zero, bit.Zero, bit.One,
+	})
+
+	// Test #2. Unsupported type.
+	_, err = NewFromWBMP(bytes.NewReader([]byte{0x01, 0x00, 0x03, 0x02, 0xA0, 0x20}))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrWbmpType)
+
+	// Test #3. Extension headers.
+	_, err = NewFromWBMP(bytes.NewReader([]byte{0x00, 0x80, 0x03, 0x02, 0xA0, 0x20}))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrWbmpExtHeader)
+
+	// Test #4. Too long multi-byte integer.
+	_, err = NewFromWBMP(bytes.NewReader([]byte{0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrWbmpMbi)
+
+	// Test #5. Zero height.
+	_, err = NewFromWBMP(bytes.NewReader([]byte{0x00, 0x00, 0x03, 0x00}))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrDimension)
+
+	// Test #6. Truncated pixels.
+	_, err = NewFromWBMP(bytes.NewReader([]byte{0x00, 0x00, 0x03, 0x02, 0xA0}))
+	tst.MustBeAnError(err)
+}
+
+func Test_appendWbmpMbi(t *testing.T) {
+
+	var err error
+	var tst *tester.Test
+	var value uint
+
+	tst = tester.New(t)
+
+	// Test #1. Known values.
+	tst.MustBeEqual(appendWbmpMbi(nil, 0), []byte{0x00})
+	tst.MustBeEqual(appendWbmpMbi(nil, 127), []byte{0x7F})
+	tst.MustBeEqual(appendWbmpMbi(nil, 128), []byte{0x81, 0x00})
+	tst.MustBeEqual(appendWbmpMbi(nil, 16384), []byte{0x81, 0x80, 0x00})
+
+	// Test #2. Round trip.
+	for _, v := range []uint{0, 1, 127, 128, 300, 16383, 16384, WbmpDimensionMax} {
+		value, err = readWbmpMbi(bytes.NewReader(appendWbmpMbi(nil, v)))
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(value, v)
+	}
+}
+
+func Fuzz_NewFromWBMP(f *testing.F) {
+	f.Add([]byte{0x00, 0x00, 0x03, 0x02, 0xA0, 0x20})
+	f.Add([]byte{0x00, 0x00, 0xFF, 0xFF, 0x7F, 0xFF, 0xFF, 0x7F})
+	f.Add([]byte{0x00, 0x80})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sbm, err := NewFromWBMP(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		buffer := new(bytes.Buffer)
+		err = sbm.WriteWBMP(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -1,8 +1,8 @@
 package sbm
 
 import (
-	"fmt"
 	"io"
+	"strconv"
 )
 
 // Write writes an SBM object into the stream.
@@ -30,59 +30,62 @@ func (sbm *Sbm) Write(writer io.Writer) (err error) {
 }
 
 func (sbm *Sbm) writeTopHeaders(writer io.Writer) (err error) {
-
-	// 1. Title.
-	_, err = writer.Write([]byte(Header_FormatName))
+	_, err = writer.Write(sbm.appendTopHeaders(nil))
 	if err != nil {
 		return err
 	}
 
+	return nil
+}
+
+// appendTopHeaders appends the top headers to the buffer.
+func (sbm *Sbm) appendTopHeaders(buf []byte) []byte {
+
+	// 1. Title.
+	buf = append(buf, Header_FormatName...)
+
 	// 2. Version.
-	_, err = writer.Write([]byte(fmt.Sprintf(HeaderFormat_Version, sbm.format.version)))
-	if err != nil {
-		return err
-	}
+	buf = append(buf, HeaderPrefix_Version+HeaderPartsSeparator...)
+	buf = strconv.AppendUint(buf, uint64(sbm.format.version), 10)
+	buf = append(buf, NL...)
 
 	// 3. Width.
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Width,
-			sbm.pixelArray.metaData.width,
-			sbm.pixelArray.metaData.header.width.topLeft,
-			sbm.pixelArray.metaData.header.width.topRight,
-		),
-	))
-	if err != nil {
-		return err
-	}
-
-	// 4. Height
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Height,
-			sbm.pixelArray.metaData.height,
-			sbm.pixelArray.metaData.header.height.topLeft,
-			sbm.pixelArray.metaData.header.height.topRight,
-		),
-	))
-	if err != nil {
-		return err
-	}
+	buf = appendSizeHeader(buf, HeaderPrefix_Width,
+		sbm.pixelArray.metaData.width,
+		sbm.pixelArray.metaData.header.width.topLeft,
+		sbm.pixelArray.metaData.header.width.topRight,
+	)
+
+	// 4. Height.
+	buf = appendSizeHeader(buf, HeaderPrefix_Height,
+		sbm.pixelArray.metaData.height,
+		sbm.pixelArray.metaData.header.height.topLeft,
+		sbm.pixelArray.metaData.header.height.topRight,
+	)
 
 	// 5. Area.
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Area,
-			sbm.pixelArray.metaData.area,
-			sbm.pixelArray.metaData.header.area.topLeft,
-			sbm.pixelArray.metaData.header.area.topRight,
-		),
-	))
-	if err != nil {
-		return err
-	}
+	buf = appendSizeHeader(buf, HeaderPrefix_Area,
+		sbm.pixelArray.metaData.area,
+		sbm.pixelArray.metaData.header.area.topLeft,
+		sbm.pixelArray.metaData.header.area.topRight,
+	)
 
-	return nil
+	return buf
+}
+
+// appendSizeHeader appends a size header to the buffer. The header has the
+// format of the HeaderFormat_Width constant.
+func appendSizeHeader(buf []byte, name string, value uint, valueLeft uint, valueRight uint) []byte {
+	buf = append(buf, name...)
+	buf = append(buf, HeaderPartsSeparator...)
+	buf = strconv.AppendUint(buf, uint64(value), 10)
+	buf = append(buf, HeaderPartsSeparator+HeaderPartsBracketLeft...)
+	buf = strconv.AppendUint(buf, uint64(valueLeft), 10)
+	buf = append(buf, HeaderPartsSeparator+HeaderPartsPlus+HeaderPartsSeparator...)
+	buf = strconv.AppendUint(buf, uint64(valueRight), 10)
+	buf = append(buf, HeaderPartsBracketRight+NL...)
+
+	return buf
 }
 
 func (sbm *Sbm) writeArrayData(writer io.Writer) (err error) {
@@ -102,45 +105,37 @@ func (sbm *Sbm) writeArrayData(writer io.Writer) (err error) {
 }
 
 func (sbm *Sbm) writeBottomHeaders(writer io.Writer) (err error) {
-
-	// 1. Width.
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Width,
-			sbm.pixelArray.metaData.width,
-			sbm.pixelArray.metaData.header.width.bottomLeft,
-			sbm.pixelArray.metaData.header.width.bottomRight,
-		),
-	))
+	_, err = writer.Write(sbm.appendBottomHeaders(nil))
 	if err != nil {
 		return err
 	}
 
-	// 2. Height
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Height,
-			sbm.pixelArray.metaData.height,
-			sbm.pixelArray.metaData.header.height.bottomLeft,
-			sbm.pixelArray.metaData.header.height.bottomRight,
-		),
-	))
-	if err != nil {
-		return err
-	}
+	return nil
+}
+
+// appendBottomHeaders appends the bottom headers to the buffer.
+func (sbm *Sbm) appendBottomHeaders(buf []byte) []byte {
+
+	// 1. Width.
+	buf = appendSizeHeader(buf, HeaderPrefix_Width,
+		sbm.pixelArray.metaData.width,
+		sbm.pixelArray.metaData.header.width.bottomLeft,
+		sbm.pixelArray.metaData.header.width.bottomRight,
+	)
+
+	// 2. Height.
+	buf = appendSizeHeader(buf, HeaderPrefix_Height,
+		sbm.pixelArray.metaData.height,
+		sbm.pixelArray.metaData.header.height.bottomLeft,
+		sbm.pixelArray.metaData.header.height.bottomRight,
+	)
 
 	// 3. Area.
-	_, err = writer.Write([]byte(
-		fmt.Sprintf(
-			HeaderFormat_Area,
-			sbm.pixelArray.metaData.area,
-			sbm.pixelArray.metaData.header.area.bottomLeft,
-			sbm.pixelArray.metaData.header.area.bottomRight,
-		),
-	))
-	if err != nil {
-		return err
-	}
+	buf = appendSizeHeader(buf, HeaderPrefix_Area,
+		sbm.pixelArray.metaData.area,
+		sbm.pixelArray.metaData.header.area.bottomLeft,
+		sbm.pixelArray.metaData.header.area.bottomRight,
+	)
 
-	return nil
+	return buf
 }
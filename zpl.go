@@ -0,0 +1,395 @@
+package sbm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vault-thirteen/auxie/bit"
+)
+
+// ZPL graphic field parameters.
+const (
+	ZplCommandGraphicField = "^GF"
+	ZplFormatASCII         = 'A'
+	ZplFormatBinary        = 'B'
+	ZplParameterSeparator  = ','
+	ZplPrefixZ64           = ":Z64:"
+	ZplPrefixB64           = ":B64:"
+	ZplRepeatCountMin      = 'G'
+	ZplRepeatCountMax      = 'Y'
+	ZplRepeatTwentiesMin   = 'g'
+	ZplRepeatTwentiesMax   = 'z'
+	ZplRepeatTwenty        = 20
+	ZplRepeatMax           = 400
+	ZplFillZeros           = ','
+	ZplFillOnes            = '!'
+	ZplRepeatLine          = ':'
+
+	// ZplParameterMax is the maximum value of the byte counts of a graphic
+	// field accepted by ZPL printers.
+	ZplParameterMax    = 99999
+	zplCommandPrefixes = "^~"
+)
+
+// Errors.
+const (
+	ErrZplFieldNotFound = "ZPL graphic field is not found"
+	ErrZplFormat        = "ZPL graphic field format is not supported"
+	ErrZplParameter     = "ZPL graphic field parameter error"
+	ErrZplData          = "ZPL graphic field data error"
+	ErrZplCrc           = "ZPL graphic field CRC mismatch"
+	ErrZplEncoding      = "ZPL encoding is not supported"
+)
+
+// WriteZPL writes an SBM object into the stream as a ZPL graphic field. In
+// ZPL the one bit is a black dot, the first dot is the most significant bit.
+func (sbm *Sbm) WriteZPL(writer io.Writer, opts ZplOptions) (err error) {
+	width := sbm.pixelArray.metaData.width
+	height := sbm.pixelArray.metaData.height
+	if (width == 0) || (height == 0) {
+		return ErrorDimension
+	}
+
+	rowSize := (width + bit.BitsPerByte - 1) / bit.BitsPerByte
+	total := rowSize * height
+
+	// 1. Field data.
+	var sb strings.Builder
+	switch opts.Encoding {
+	case ZplEncodingHex:
+		for y := uint(0); y < height; y++ {
+			sb.WriteString("\n")
+			sb.WriteString(strings.ToUpper(hex.EncodeToString(sbm.getRowBytesMSB(y, true))))
+		}
+
+	case ZplEncodingCompressed:
+		var prevRow string
+		for y := uint(0); y < height; y++ {
+			row := strings.ToUpper(hex.EncodeToString(sbm.getRowBytesMSB(y, true)))
+			sb.WriteString("\n")
+			if (y > 0) && (row == prevRow) {
+				sb.WriteByte(ZplRepeatLine)
+			} else {
+				sb.WriteString(zplCompressRow(row))
+			}
+			prevRow = row
+		}
+
+	case ZplEncodingZ64:
+		raw := new(bytes.Buffer)
+		zw := zlib.NewWriter(raw)
+		for y := uint(0); y < height; y++ {
+			_, err = zw.Write(sbm.getRowBytesMSB(y, true))
+			if err != nil {
+				return err
+			}
+		}
+		err = zw.Close()
+		if err != nil {
+			return err
+		}
+		b64 := base64.StdEncoding.EncodeToString(raw.Bytes())
+		sb.WriteString(ZplPrefixZ64)
+		sb.WriteString(b64)
+		sb.WriteString(fmt.Sprintf(":%04X", crc16Xmodem([]byte(b64))))
+
+	default:
+		return errors.New(ErrZplEncoding)
+	}
+
+	// 2. Field.
+	field := fmt.Sprintf("%s%c,%d,%d,%d,%s", ZplCommandGraphicField, ZplFormatASCII, total, total, rowSize, sb.String())
+	if opts.Label {
+		field = fmt.Sprintf("^XA\n^FO%d,%d%s^FS\n^XZ\n", opts.X, opts.Y, field)
+	} else {
+		field += "\n"
+	}
+
+	_, err = writer.Write([]byte(field))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// zplCompressRow compresses a row of hexadecimal data with the ZPL run-length
+// letter codes.
+func zplCompressRow(row string) string {
+	var sb strings.Builder
+
+	// Trailing zeros or ones are replaced with a single fill character.
+	var fill byte
+	if trimmed := strings.TrimRight(row, "0"); len(trimmed) < len(row) {
+		row, fill = trimmed, ZplFillZeros
+	} else if trimmed = strings.TrimRight(row, "F"); len(trimmed) < len(row) {
+		row, fill = trimmed, ZplFillOnes
+	}
+
+	for i := 0; i < len(row); {
+		j := i + 1
+		for (j < len(row)) && (row[j] == row[i]) {
+			j++
+		}
+		if j-i > 1 {
+			sb.WriteString(zplRepeatCount(uint(j - i)))
+		}
+		sb.WriteByte(row[i])
+		i = j
+	}
+
+	if fill != 0 {
+		sb.WriteByte(fill)
+	}
+
+	return sb.String()
+}
+
+// zplRepeatCount returns letter codes of a repeat count.
+func zplRepeatCount(n uint) string {
+	var sb strings.Builder
+	for ; n >= ZplRepeatMax; n -= ZplRepeatMax {
+		sb.WriteByte(ZplRepeatTwentiesMax)
+	}
+	if n >= ZplRepeatTwenty {
+		sb.WriteByte(byte(ZplRepeatTwentiesMin + n/ZplRepeatTwenty - 1))
+		n %= ZplRepeatTwenty
+	}
+	if n > 0 {
+		sb.WriteByte(byte(ZplRepeatCountMin + n - 1))
+	}
+
+	return sb.String()
+}
+
+// NewFromZPL reads an SBM object from the stream containing a ZPL graphic
+// field. The first graphic field of the stream is used. As ZPL does not store
+// the width in dots, width of the image is the number of bytes per row
+// multiplied by eight.
+func NewFromZPL(reader io.Reader) (sbm *Sbm, err error) {
+	return NewFromZPLWithOptions(reader, DecodeOptions{})
+}
+
+// NewFromZPLWithOptions reads an SBM object from the stream containing a ZPL
+// graphic field refusing the images which exceed the limits of the options.
+// Only the dimension limits of the options are used.
+func NewFromZPLWithOptions(reader io.Reader, opts DecodeOptions) (sbm *Sbm, err error) {
+	var data []byte
+	data, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Parameters.
+	i := bytes.Index(data, []byte(ZplCommandGraphicField))
+	if i < 0 {
+		return nil, errors.New(ErrZplFieldNotFound)
+	}
+	data = data[i+len(ZplCommandGraphicField):]
+
+	var params [4]string
+	for p := range params {
+		j := bytes.IndexByte(data, ZplParameterSeparator)
+		if j < 0 {
+			return nil, errors.New(ErrZplParameter)
+		}
+		params[p] = strings.TrimSpace(string(data[:j]))
+		data = data[j+1:]
+	}
+
+	var total, fieldSize, rowSize uint64
+	total, err = strconv.ParseUint(params[1], 10, 32)
+	if err != nil {
+		return nil, errors.New(ErrZplParameter)
+	}
+	fieldSize, err = strconv.ParseUint(params[2], 10, 32)
+	if err != nil {
+		return nil, errors.New(ErrZplParameter)
+	}
+	rowSize, err = strconv.ParseUint(params[3], 10, 32)
+	if err != nil {
+		return nil, errors.New(ErrZplParameter)
+	}
+	if (total == 0) || (rowSize == 0) || (total > ZplParameterMax) || (fieldSize > ZplParameterMax) ||
+		(rowSize > ZplParameterMax) || (total%rowSize != 0) {
+		return nil, errors.New(ErrZplParameter)
+	}
+	height := uint(total / rowSize)
+	if area, ok := multiplyDimensions(uint(rowSize), height); !ok || (area != uint(total)) {
+		return nil, errors.New(ErrZplParameter)
+	}
+	width, ok := multiplyDimensions(uint(rowSize), bit.BitsPerByte)
+	if !ok {
+		return nil, errors.New(ErrZplParameter)
+	}
+	_, err = checkDimensions(width, height, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Data.
+	var pixels []byte
+	switch params[0] {
+	case string(ZplFormatASCII):
+		if j := bytes.IndexAny(data, zplCommandPrefixes); j >= 0 {
+			data = data[:j]
+		}
+		pixels, err = zplDecodeASCII(string(bytes.TrimSpace(data)), uint(rowSize), uint(total))
+		if err != nil {
+			return nil, err
+		}
+
+	case string(ZplFormatBinary):
+		if (fieldSize != total) || (uint64(len(data)) < fieldSize) {
+			return nil, errors.New(ErrZplData)
+		}
+		pixels = data[:fieldSize]
+
+	default:
+		return nil, errors.New(ErrZplFormat)
+	}
+
+	var bits []bit.Bit
+	for y := uint(0); y < height; y++ {
+		bits = appendRowBitsMSB(bits, pixels[y*uint(rowSize):], width, true)
+	}
+
+	return NewFromBitsArray(bits, width, height)
+}
+
+// zplDecodeASCII decodes ASCII data of a graphic field, which is either
+// hexadecimal, compressed hexadecimal or Base64.
+func zplDecodeASCII(data string, rowSize uint, total uint) (pixels []byte, err error) {
+	if !strings.HasPrefix(data, ZplPrefixZ64) && !strings.HasPrefix(data, ZplPrefixB64) {
+		return zplDecodeHex(data, rowSize, total)
+	}
+
+	// Base64 data is followed by an optional CRC.
+	b64, crc, hasCrc := strings.Cut(data[len(ZplPrefixZ64):], ":")
+	if hasCrc && (len(crc) > 0) {
+		var v uint64
+		v, err = strconv.ParseUint(crc, 16, 16)
+		if err != nil {
+			return nil, errors.New(ErrZplData)
+		}
+		if uint16(v) != crc16Xmodem([]byte(b64)) {
+			return nil, errors.New(ErrZplCrc)
+		}
+	}
+
+	var raw []byte
+	raw, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(b64, "="))
+	if err != nil {
+		return nil, errors.New(ErrZplData)
+	}
+
+	if strings.HasPrefix(data, ZplPrefixZ64) {
+		var zr io.ReadCloser
+		zr, err = zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, errors.New(ErrZplData)
+		}
+		raw, err = io.ReadAll(io.LimitReader(zr, int64(total)+1))
+		if err != nil {
+			return nil, errors.New(ErrZplData)
+		}
+	}
+
+	if uint(len(raw)) != total {
+		return nil, errors.New(ErrZplData)
+	}
+
+	return raw, nil
+}
+
+// zplDecodeHex decodes hexadecimal data of a graphic field, which may be
+// compressed with the ZPL run-length letter codes.
+func zplDecodeHex(data string, rowSize uint, total uint) (pixels []byte, err error) {
+	// Every row takes at least one character even when it is compressed.
+	if total/rowSize > uint(len(data)) {
+		return nil, errors.New(ErrZplData)
+	}
+
+	lineSize := 2 * rowSize
+	var line []byte
+	var prevRow []byte
+	var count uint
+
+	flush := func(fill byte) error {
+		for uint(len(line)) < lineSize {
+			line = append(line, fill)
+		}
+		row := make([]byte, rowSize)
+		_, err := hex.Decode(row, line)
+		if err != nil {
+			return errors.New(ErrZplData)
+		}
+		pixels = append(pixels, row...)
+		prevRow = row
+		line = line[:0]
+		return nil
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case (c == ' ') || (c == CR) || (c == LF) || (c == '\t'):
+			continue
+
+		case (c >= ZplRepeatCountMin) && (c <= ZplRepeatCountMax):
+			count += uint(c-ZplRepeatCountMin) + 1
+
+		case (c >= ZplRepeatTwentiesMin) && (c <= ZplRepeatTwentiesMax):
+			count += (uint(c-ZplRepeatTwentiesMin) + 1) * ZplRepeatTwenty
+
+		case c == ZplFillZeros:
+			count = 0
+			err = flush('0')
+
+		case c == ZplFillOnes:
+			count = 0
+			err = flush('F')
+
+		case c == ZplRepeatLine:
+			if (len(line) != 0) || (prevRow == nil) {
+				return nil, errors.New(ErrZplData)
+			}
+			pixels = append(pixels, prevRow...)
+
+		case ((c >= '0') && (c <= '9')) || ((c >= 'A') && (c <= 'F')) || ((c >= 'a') && (c <= 'f')):
+			n := max(count, 1)
+			count = 0
+			if uint(len(line))+n > lineSize {
+				return nil, errors.New(ErrZplData)
+			}
+			for ; n > 0; n-- {
+				line = append(line, c)
+			}
+			if uint(len(line)) == lineSize {
+				err = flush('0')
+			}
+
+		default:
+			return nil, errors.New(ErrZplData)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(pixels)) > total {
+			return nil, errors.New(ErrZplData)
+		}
+	}
+
+	if (len(line) != 0) || (count != 0) || (uint(len(pixels)) != total) {
+		return nil, errors.New(ErrZplData)
+	}
+
+	return pixels, nil
+}
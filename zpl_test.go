@@ -0,0 +1,126 @@
+package sbm
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/bit"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_WriteZPL(t *testing.T) {
+
+	var buffer *bytes.Buffer
+	var err error
+	var sbm *Sbm
+	var sbm2 *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// 16x3 image, black is zero.
+	bits := make([]bit.Bit, 0, 48)
+	for _, row := range []uint16{0x0FFF, 0x0FFF, 0xFFFE} {
+		for i := 15; i >= 0; i-- {
+			bits = append(bits, bit.Bit((row>>i)&1 == 1))
+		}
+	}
+	sbm, err = NewFromBitsArray(bits, 16, 3)
+	tst.MustBeNoError(err)
+
+	// Test #1. Hexadecimal data.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteZPL(buffer, ZplOptions{})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "^GFA,6,6,2,\nF000\nF000\n0001\n")
+
+	// Test #2. Compressed data in a label.
+	buffer = new(bytes.Buffer)
+	err = sbm.WriteZPL(buffer, ZplOptions{Encoding: ZplEncodingCompressed, Label: true, X: 10, Y: 20})
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(buffer.String(), "^XA\n^FO10,20^GFA,6,6,2,\nF,\n:\nI01^FS\n^XZ\n")
+
+	// Test #3. Round trips.
+	for _, encoding := range []byte{ZplEncodingHex, ZplEncodingCompressed, ZplEncodingZ64} {
+		buffer = new(bytes.Buffer)
+		err = sbm.WriteZPL(buffer, ZplOptions{Encoding: encoding, Label: true})
+		tst.MustBeNoError(err)
+		sbm2, err = NewFromZPL(buffer)
+		tst.MustBeNoError(err)
+		tst.MustBeEqual(sbm2.GetArrayBits(), sbm.GetArrayBits())
+	}
+
+	// Test #4. Unknown encoding.
+	err = sbm.WriteZPL(new(bytes.Buffer), ZplOptions{Encoding: 100})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplEncoding)
+}
+
+func Test_zplRepeatCount(t *testing.T) {
+	tst := tester.New(t)
+	tst.MustBeEqual(zplRepeatCount(1), "G")
+	tst.MustBeEqual(zplRepeatCount(19), "Y")
+	tst.MustBeEqual(zplRepeatCount(20), "g")
+	tst.MustBeEqual(zplRepeatCount(45), "hK")
+	tst.MustBeEqual(zplRepeatCount(400), "z")
+	tst.MustBeEqual(zplRepeatCount(821), "zzgG")
+}
+
+func Test_NewFromZPL(t *testing.T) {
+
+	var err error
+	var sbm *Sbm
+	var tst *tester.Test
+
+	tst = tester.New(t)
+
+	// Test #1. Compressed data with all the codes.
+	sbm, err = NewFromZPL(strings.NewReader("^XA^FO0,0^GFA,4,4,1,HF!\n:,^FS^XZ"))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayWidth(), uint(8))
+	tst.MustBeEqual(sbm.GetArrayHeight(), uint(4))
+	tst.MustBeEqual(sbm.GetArrayBytes(), []byte{0x00, 0x00, 0x00, 0xFF})
+
+	// Test #2. Binary data.
+	sbm, err = NewFromZPL(strings.NewReader("^GFB,2,2,1,\x0F\xF0^FS"))
+	tst.MustBeNoError(err)
+	tst.MustBeEqual(sbm.GetArrayBytes(), []byte{0x0F, 0xF0})
+
+	// Test #3. CRC mismatch.
+	_, err = NewFromZPL(strings.NewReader("^GFA,1,1,1,:Z64:eJz7DwABAQEA:0000"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplCrc)
+
+	// Test #4. Too much data.
+	_, err = NewFromZPL(strings.NewReader("^GFA,1,1,1,FFFF"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplData)
+
+	// Test #5. Bad parameters.
+	_, err = NewFromZPL(strings.NewReader("^GFA,3,3,2,FFFFFF"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplParameter)
+
+	// Test #6. No field.
+	_, err = NewFromZPL(strings.NewReader("^XA^XZ"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplFieldNotFound)
+
+	// Test #7. Oversized field.
+	_, err = NewFromZPL(strings.NewReader("^GFA,4000000000,4000000000,4000000000,"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplParameter)
+	_, err = NewFromZPL(strings.NewReader("^GFA,99990,99990,10,,"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplData)
+	_, err = NewFromZPL(strings.NewReader("^GFB,99990,99990,10,\x00"))
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(err.Error(), ErrZplData)
+
+	// Test #8. Limits.
+	_, err = NewFromZPLWithOptions(strings.NewReader("^GFA,4,4,1,HF!\n:,^FS"), DecodeOptions{MaxHeight: 3})
+	tst.MustBeAnError(err)
+	tst.MustBeEqual(errors.Is(err, ErrorLimit), true)
+}